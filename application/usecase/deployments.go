@@ -0,0 +1,82 @@
+package usecase
+
+import (
+	"context"
+	"sort"
+
+	"github.com/dragoneena12/measure-review-time/domain/entity"
+)
+
+func (o MeasureOptions) measureDeployments() bool {
+	return o.DeployEnvironment != "" || o.DeployFromReleases
+}
+
+// attributeDeployments sets DeployedAt of each merged PR to the first deployment after
+// the merge that contains its merge commit. PRs not deployed yet keep a nil DeployedAt.
+func (u *MeasureReviewTimeUseCase) attributeDeployments(ctx context.Context, opts MeasureOptions, prs []*entity.PullRequest) error {
+	var earliestMerge *entity.PullRequest
+	for _, pr := range prs {
+		if pr.MergedAt == nil || pr.MergeCommitSHA == "" {
+			continue
+		}
+		if earliestMerge == nil || pr.MergedAt.Before(*earliestMerge.MergedAt) {
+			earliestMerge = pr
+		}
+	}
+	if earliestMerge == nil {
+		return nil
+	}
+
+	var deployments []entity.Deployment
+	var err error
+	if opts.DeployEnvironment != "" {
+		deployments, err = u.deployRepo.ListDeployments(ctx, opts.Owner, opts.Repo, opts.DeployEnvironment, *earliestMerge.MergedAt)
+	} else {
+		deployments, err = u.deployRepo.ListReleases(ctx, opts.Owner, opts.Repo, *earliestMerge.MergedAt)
+	}
+	if err != nil {
+		return err
+	}
+
+	// A ref is a deployed commit SHA or a release tag. The same commit is often deployed again,
+	// for a retry or a rollback, and each comparison is an API call
+	type refCommit struct{ ref, sha string }
+	containsCache := make(map[refCommit]bool)
+	containsCommit := func(ref, sha string) (bool, error) {
+		key := refCommit{ref, sha}
+		if contains, ok := containsCache[key]; ok {
+			return contains, nil
+		}
+		contains, err := u.deployRepo.ContainsCommit(ctx, opts.Owner, opts.Repo, ref, sha)
+		if err != nil {
+			return false, err
+		}
+		containsCache[key] = contains
+		return contains, nil
+	}
+
+	for _, pr := range prs {
+		if pr.MergedAt == nil || pr.MergeCommitSHA == "" {
+			continue
+		}
+
+		// Deployments are sorted oldest first, so those before the merge are skipped without
+		// comparing them
+		first := sort.Search(len(deployments), func(i int) bool {
+			return !deployments[i].At.Before(*pr.MergedAt)
+		})
+		for _, d := range deployments[first:] {
+			contains, err := containsCommit(d.Ref, pr.MergeCommitSHA)
+			if err != nil {
+				return err
+			}
+			if contains {
+				at := d.At
+				pr.DeployedAt = &at
+				break
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,124 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"strings"
+
+	"github.com/dragoneena12/measure-review-time/domain/entity"
+)
+
+const (
+	// bootstrapResamples and confidenceLevel set up the confidence interval of median differences
+	bootstrapResamples = 2000
+	confidenceLevel    = 0.95
+)
+
+// MeasureComparisonUseCase compares the review metrics of two groups of PRs, such as two
+// periods before and after a process change, or two teams of authors
+type MeasureComparisonUseCase struct {
+	measure *MeasureReviewTimeUseCase
+}
+
+func NewMeasureComparisonUseCase(measure *MeasureReviewTimeUseCase) *MeasureComparisonUseCase {
+	return &MeasureComparisonUseCase{
+		measure: measure,
+	}
+}
+
+// Execute measures the PRs created in the baseline period and in the period of opts,
+// and compares each metric between them. Each period counts its filter exclusions apart.
+func (u *MeasureComparisonUseCase) Execute(ctx context.Context, opts MeasureOptions, baseline entity.Period) (*entity.ComparisonReport, error) {
+	current := entity.Period{Since: opts.Since, Until: opts.Until}
+	filter := opts.Filter
+
+	opts.Filter = filter.Fresh()
+	currentReport, err := u.measure.Execute(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	opts.Since, opts.Until = baseline.Since, baseline.Until
+	opts.Filter = filter.Fresh()
+	baselineReport, err := u.measure.Execute(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to measure the baseline period: %w", err)
+	}
+
+	report := compare(baseline.String(), baselineReport.Metrics, current.String(), currentReport.Metrics, opts.CombineOutcomes, opts.excludeOutliers())
+	report.Owner = opts.Owner
+	report.Repo = opts.Repo
+	report.Baseline.Exclusions = baselineReport.Exclusions
+	report.Current.Exclusions = currentReport.Exclusions
+	return report, nil
+}
+
+// ExecuteGroups measures the PRs once and compares the PRs matching the baseline values along
+// the dimension with those matching the current values, e.g. two sets of authors
+func (u *MeasureComparisonUseCase) ExecuteGroups(ctx context.Context, opts MeasureOptions, dimension entity.ComparisonDimension, baseline, current []string) (*entity.ComparisonReport, error) {
+	measured, err := u.measure.Execute(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// A PR in both groups would make them look more alike than they are, so it is left out
+	var baselineMetrics, currentMetrics []*entity.ReviewMetrics
+	var overlapping int
+	for _, m := range measured.Metrics {
+		inBaseline, inCurrent := dimension.Matches(m, baseline), dimension.Matches(m, current)
+		switch {
+		case inBaseline && inCurrent:
+			overlapping++
+		case inBaseline:
+			baselineMetrics = append(baselineMetrics, m)
+		case inCurrent:
+			currentMetrics = append(currentMetrics, m)
+		}
+	}
+
+	label := func(values []string) string {
+		return fmt.Sprintf("%s: %s", dimension, strings.Join(values, ", "))
+	}
+	report := compare(label(baseline), baselineMetrics, label(current), currentMetrics, opts.CombineOutcomes, opts.excludeOutliers())
+	report.Owner = opts.Owner
+	report.Repo = opts.Repo
+	report.Overlapping = overlapping
+	report.Exclusions = measured.Exclusions
+	return report, nil
+}
+
+// compare summarizes both groups of PRs and compares every duration metric measured in either,
+// over the merged PRs unless combineOutcomes, and leaving out outliers with excludeOutliers
+func compare(baselineLabel string, baseline []*entity.ReviewMetrics, currentLabel string, current []*entity.ReviewMetrics, combineOutcomes, excludeOutliers bool) *entity.ComparisonReport {
+	baseline, outcome := summarized(baseline, combineOutcomes)
+	current, _ = summarized(current, combineOutcomes)
+	report := &entity.ComparisonReport{
+		Baseline: entity.ComparisonGroup{Label: baselineLabel, Summary: *summarize(baseline, outcome, excludeOutliers)},
+		Current:  entity.ComparisonGroup{Label: currentLabel, Summary: *summarize(current, outcome, excludeOutliers)},
+	}
+
+	// A fixed seed keeps the confidence intervals of a report reproducible
+	rng := rand.New(rand.NewPCG(1, 2))
+	var tests []*entity.RankTest
+	for _, metric := range entity.DurationMetrics {
+		a, _ := durationsOf(metric, baseline, excludeOutliers)
+		b, _ := durationsOf(metric, current, excludeOutliers)
+		if len(a) == 0 && len(b) == 0 {
+			continue
+		}
+		test := entity.MannWhitneyU(a, b)
+		tests = append(tests, test)
+		report.Metrics = append(report.Metrics, entity.MetricComparison{
+			Metric:           metric,
+			Baseline:         entity.Summarize(a),
+			Current:          entity.Summarize(b),
+			MedianDifference: entity.BootstrapMedianDifference(a, b, bootstrapResamples, confidenceLevel, rng),
+			Test:             test,
+		})
+	}
+
+	// Testing every metric at once would find some difference by chance
+	entity.AdjustHolm(tests)
+	return report
+}
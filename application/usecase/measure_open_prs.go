@@ -0,0 +1,33 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/dragoneena12/measure-review-time/domain/entity"
+)
+
+// MeasureOpenPRsUseCase reports the open PRs waiting for review right now
+type MeasureOpenPRsUseCase struct {
+	measure *MeasureReviewTimeUseCase
+}
+
+func NewMeasureOpenPRsUseCase(measure *MeasureReviewTimeUseCase) *MeasureOpenPRsUseCase {
+	return &MeasureOpenPRsUseCase{
+		measure: measure,
+	}
+}
+
+// Execute measures open, non-draft PRs and how long they have waited as of now
+func (u *MeasureOpenPRsUseCase) Execute(ctx context.Context, opts MeasureOptions, now time.Time) (*entity.AgingReport, error) {
+	opts.State = "open"
+	opts.ExcludeDrafts = true
+	opts.Now = now
+
+	report, err := u.measure.Execute(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return entity.NewAgingReport(report, now), nil
+}
@@ -3,6 +3,9 @@ package usecase
 import (
 	"context"
 	"fmt"
+	"slices"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/dragoneena12/measure-review-time/domain/entity"
@@ -10,26 +13,102 @@ import (
 )
 
 type MeasureReviewTimeUseCase struct {
-	prRepo repository.PullRequestRepository
+	prRepo         repository.PullRequestRepository
+	ownersRepo     repository.CodeOwnersRepository
+	protectionRepo repository.BranchProtectionRepository
+	deployRepo     repository.DeploymentRepository
 }
 
-func NewMeasureReviewTimeUseCase(prRepo repository.PullRequestRepository) *MeasureReviewTimeUseCase {
+func NewMeasureReviewTimeUseCase(prRepo repository.PullRequestRepository, ownersRepo repository.CodeOwnersRepository, protectionRepo repository.BranchProtectionRepository, deployRepo repository.DeploymentRepository) *MeasureReviewTimeUseCase {
 	return &MeasureReviewTimeUseCase{
-		prRepo: prRepo,
+		prRepo:         prRepo,
+		ownersRepo:     ownersRepo,
+		protectionRepo: protectionRepo,
+		deployRepo:     deployRepo,
 	}
 }
 
 type MeasureOptions struct {
-	Owner string
-	Repo  string
-	State string
-	Since *time.Time
-	Until *time.Time
+	Owner  string
+	Repo   string
+	State  string
+	Since  *time.Time
+	Until  *time.Time
+	Filter *entity.AccountFilter
+	Sizes  entity.SizeThresholds
+
+	Labels  []string
+	Base    string
+	Authors []string
+	Paths   []*entity.PathGlob
+
+	// CodeOwners attributes review time to owning areas. When nil and FetchCodeOwners
+	// is set, the CODEOWNERS file is fetched from the default branch.
+	CodeOwners      *entity.CodeOwners
+	FetchCodeOwners bool
+
+	// RequiredApprovals is the number of approvals needed to merge. When zero, it is read
+	// from branch protection if ApprovalsFromProtection is set, and defaults to one.
+	RequiredApprovals       int
+	ApprovalsFromProtection bool
+
+	ExcludeDrafts bool
+
+	// CodingTime fetches the commits of each PR to measure the time from the first commit
+	// to PR creation. Commits authored more than MaxCommitAge before it are ignored when positive.
+	CodingTime   bool
+	MaxCommitAge time.Duration
+
+	// DeployEnvironment finds when merged PRs reached this environment using the Deployments API.
+	// Otherwise DeployFromReleases uses the first release whose tag contains the merge commit.
+	DeployEnvironment  string
+	DeployFromReleases bool
+
+	// Rework measures the commits pushed after the first review, aggregated per team of the author
+	Rework bool
+	Teams  entity.Teams
+
+	// BallInCourt splits the open time of each PR into waiting on reviewers, on the author and
+	// idle after approval, aggregated per team of the author. Open PRs are measured until Now,
+	// and skipped when it is not set. Without Until, reviewer load is also measured until Now.
+	BallInCourt bool
+	Now         time.Time
+
+	// AuthorResponse measures the time from CHANGES_REQUESTED or COMMENTED reviews until
+	// the author's next push or reply, per author and for the repository
+	AuthorResponse bool
+
+	// Survival estimates the share of PRs still waiting for a review or approval with
+	// Kaplan-Meier, counting PRs closed without one, or open until Now, as censored
+	Survival bool
+
+	// Outliers detects outliers of every duration metric with the method when set.
+	// ExcludeOutliers leaves them out of the summary statistics; they are still listed.
+	Outliers        entity.OutlierMethod
+	ExcludeOutliers bool
+
+	// RubberStamps flags approvals matching the rule when set
+	RubberStamps *entity.RubberStampRule
+
+	// ReviewerLoad reports the pending review requests of each reviewer over the window
+	ReviewerLoad bool
+
+	// CombineOutcomes aggregates merged, closed and open PRs together instead of per outcome
+	CombineOutcomes bool
 }
 
-func (u *MeasureReviewTimeUseCase) Execute(ctx context.Context, opts MeasureOptions) ([]*entity.ReviewMetrics, error) {
+func (u *MeasureReviewTimeUseCase) Execute(ctx context.Context, opts MeasureOptions) (*entity.Report, error) {
 	metrics := make([]*entity.ReviewMetrics, 0)
 
+	codeOwners := opts.CodeOwners
+	if codeOwners == nil && opts.FetchCodeOwners {
+		co, err := u.ownersRepo.GetCodeOwners(ctx, opts.Owner, opts.Repo)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get CODEOWNERS: %w", err)
+		}
+		codeOwners = co
+	}
+
 	listOpts := repository.ListOptions{
 		State:     opts.State,
 		Sort:      "created",
@@ -37,6 +116,17 @@ func (u *MeasureReviewTimeUseCase) Execute(ctx context.Context, opts MeasureOpti
 		Since:     opts.Since,
 		Until:     opts.Until,
 		PerPage:   100,
+		Filter:    opts.Filter,
+		Labels:    opts.Labels,
+		Base:      opts.Base,
+		Authors:   opts.Authors,
+		Paths:     opts.Paths,
+
+		ExcludeDrafts: opts.ExcludeDrafts,
+		FetchFiles:    codeOwners != nil,
+		FetchCommits:  opts.CodingTime || opts.measureDeployments() || opts.BallInCourt || opts.AuthorResponse,
+
+		FetchReworkStats: opts.Rework,
 	}
 
 	prs, err := u.prRepo.List(ctx, opts.Owner, opts.Repo, listOpts)
@@ -44,10 +134,261 @@ func (u *MeasureReviewTimeUseCase) Execute(ctx context.Context, opts MeasureOpti
 		return nil, fmt.Errorf("failed to list pull requests: %w", err)
 	}
 
+	if opts.measureDeployments() {
+		if err := u.attributeDeployments(ctx, opts, prs); err != nil {
+			return nil, fmt.Errorf("failed to find deployments: %w", err)
+		}
+	}
+
+	requiredApprovals := make(map[string]int)
 	for _, pr := range prs {
-		metric := pr.CalculateMetrics()
+		required, ok := requiredApprovals[pr.BaseBranch]
+		if !ok {
+			required = u.requiredApprovals(ctx, opts, pr.BaseBranch)
+			requiredApprovals[pr.BaseBranch] = required
+		}
+		pr.RequiredApprovals = required
+
+		metric := pr.CalculateMetrics(opts.Sizes)
+		if opts.CodingTime {
+			metric.CodingTime = pr.CodingTime(opts.MaxCommitAge)
+		}
+		if first := pr.FirstCommitAt(opts.MaxCommitAge); first != nil && pr.DeployedAt != nil {
+			duration := pr.DeployedAt.Sub(*first)
+			metric.CommitToDeploy = &duration
+		}
+		if opts.BallInCourt && (pr.ClosedAt != nil || !opts.Now.IsZero()) {
+			b := pr.BallInCourt(opts.Now)
+			metric.BallInCourt = &b
+		}
+		if opts.AuthorResponse {
+			metric.FeedbackResponses = pr.FeedbackResponses()
+		}
+		if opts.RubberStamps != nil {
+			metric.RubberStamps = opts.RubberStamps.Check(pr)
+		}
+		if opts.Rework {
+			metric.Rework = pr.Rework()
+			if metric.Rework != nil && metric.Rework.LastPushAt != nil {
+				duration := metric.Rework.LastPushAt.Sub(*pr.FirstReviewAt)
+				metric.ReviewToLastPush = &duration
+			}
+		}
 		metrics = append(metrics, metric)
 	}
 
-	return metrics, nil
+	var outliers *entity.OutlierDetection
+	if opts.Outliers != "" {
+		outliers = &entity.OutlierDetection{
+			Method:   opts.Outliers,
+			Excluded: opts.ExcludeOutliers,
+			Fences:   detectOutliers(metrics, opts.Outliers),
+		}
+	}
+
+	summarizedMetrics, outcome := summarized(metrics, opts.CombineOutcomes)
+	report := &entity.Report{
+		Owner:      opts.Owner,
+		Repo:       opts.Repo,
+		Metrics:    metrics,
+		Exclusions: opts.Filter.Exclusions(),
+		Measured: entity.MeasuredMetrics{
+			CodingTime:     opts.CodingTime,
+			Deployments:    opts.measureDeployments(),
+			Rework:         opts.Rework,
+			BallInCourt:    opts.BallInCourt,
+			AuthorResponse: opts.AuthorResponse,
+		},
+		Summary:  summarize(summarizedMetrics, outcome, opts.excludeOutliers()),
+		Outliers: outliers,
+		Outcomes: entity.GroupLatency(metrics, []string{allPRs}, func(*entity.ReviewMetrics) []string {
+			return []string{allPRs}
+		}, true),
+		SizeBuckets: entity.GroupLatency(metrics, sizeBucketKeys(), func(m *entity.ReviewMetrics) []string {
+			return []string{string(m.Size)}
+		}, !opts.CombineOutcomes),
+	}
+
+	if opts.Rework {
+		report.Rework = entity.GroupRework(metrics, opts.Teams)
+	}
+
+	if opts.BallInCourt {
+		report.TeamWaits = entity.GroupBallInCourt(metrics, opts.Teams)
+	}
+
+	if opts.Survival {
+		report.Survival = entity.EstimateSurvival(metrics, opts.Now)
+	}
+
+	if opts.AuthorResponse {
+		authors, repo := entity.GroupAuthorResponses(metrics)
+		report.AuthorResponses = authors
+		report.RepoAuthorResponse = &repo
+	}
+
+	if opts.RubberStamps != nil {
+		report.RubberStampRule = opts.RubberStamps
+		report.RubberStamps = entity.CountRubberStamps(metrics)
+	}
+
+	if opts.ReviewerLoad {
+		teamMembers := u.requestedTeamMembers(ctx, metrics)
+		from, to := loadWindow(opts, metrics, teamMembers)
+		report.ReviewerLoad = entity.NewReviewerLoads(metrics, teamMembers, from, to)
+	}
+
+	if codeOwners != nil {
+		report.Areas = u.attributeAreas(ctx, codeOwners, metrics, !opts.CombineOutcomes)
+	}
+
+	return report, nil
+}
+
+// excludeOutliers tells whether outliers are detected and left out of summary statistics
+func (o MeasureOptions) excludeOutliers() bool {
+	return o.Outliers != "" && o.ExcludeOutliers
+}
+
+// requiredApprovals resolves the number of approvals needed to merge into the branch
+func (u *MeasureReviewTimeUseCase) requiredApprovals(ctx context.Context, opts MeasureOptions, branch string) int {
+	if opts.RequiredApprovals > 0 {
+		return opts.RequiredApprovals
+	}
+	if opts.ApprovalsFromProtection && branch != "" {
+		// Unprotected branches, or tokens without admin access, fall back to one approval
+		if required, err := u.protectionRepo.GetRequiredApprovals(ctx, opts.Owner, opts.Repo, branch); err == nil && required > 0 {
+			return required
+		}
+	}
+	return 1
+}
+
+// attributeAreas maps each PR to the CODEOWNERS areas of its files and aggregates latency per area
+func (u *MeasureReviewTimeUseCase) attributeAreas(ctx context.Context, codeOwners *entity.CodeOwners, metrics []*entity.ReviewMetrics, byOutcome bool) []entity.AreaLatency {
+	// Team members are resolved once per team. Teams that can't be resolved,
+	// e.g. for lack of read:org permission, leave code owner approval undecided.
+	teamMembers := make(map[string][]string)
+	resolved := make(map[string]bool)
+
+	areaSet := make(map[string]bool)
+	for _, m := range metrics {
+		m.Areas = codeOwners.AreasOf(m.PullRequest.Files)
+		for _, area := range m.Areas {
+			areaSet[area] = true
+
+			if entity.IsTeamOwner(area) && !resolved[area] {
+				resolved[area] = true
+				org, slug, _ := strings.Cut(strings.TrimPrefix(area, "@"), "/")
+				if members, err := u.ownersRepo.ListTeamMembers(ctx, org, slug); err == nil {
+					teamMembers[area] = members
+				}
+			}
+		}
+		m.CodeOwnerApproved = entity.ApprovedByCodeOwner(m.PullRequest.Approvals, m.Areas, teamMembers)
+	}
+
+	keys := make([]string, 0, len(areaSet))
+	for area := range areaSet {
+		if area != entity.UnownedArea {
+			keys = append(keys, area)
+		}
+	}
+	sort.Strings(keys)
+	if areaSet[entity.UnownedArea] {
+		keys = append(keys, entity.UnownedArea)
+	}
+
+	groups := entity.GroupLatency(metrics, keys, func(m *entity.ReviewMetrics) []string {
+		return m.Areas
+	}, byOutcome)
+
+	areas := make([]entity.AreaLatency, 0, len(groups))
+	for _, g := range groups {
+		area := entity.AreaLatency{LatencyGroup: g}
+		for _, m := range metrics {
+			if !slices.Contains(m.Areas, g.Key) || len(m.PullRequest.Approvals) == 0 {
+				continue
+			}
+			if g.Outcome != "" && m.Outcome != g.Outcome {
+				continue
+			}
+			// Only approvals by the owners of this particular area count for it
+			approved := entity.ApprovedByCodeOwner(m.PullRequest.Approvals, []string{g.Key}, teamMembers)
+			if approved == nil {
+				continue
+			}
+			area.Approved++
+			if *approved {
+				area.CodeOwnerApproved++
+			}
+		}
+		areas = append(areas, area)
+	}
+
+	return areas
+}
+
+// requestedTeamMembers resolves the members of the teams requested to review the PRs, once per
+// team. Teams that can't be resolved, e.g. for lack of read:org permission, are left out.
+func (u *MeasureReviewTimeUseCase) requestedTeamMembers(ctx context.Context, metrics []*entity.ReviewMetrics) map[string][]string {
+	teamMembers := make(map[string][]string)
+	resolved := make(map[string]bool)
+	for _, m := range metrics {
+		for _, e := range m.PullRequest.ReviewRequests {
+			if !entity.IsTeamOwner(e.Reviewer) || resolved[e.Reviewer] {
+				continue
+			}
+			resolved[e.Reviewer] = true
+			org, slug, _ := strings.Cut(strings.TrimPrefix(e.Reviewer, "@"), "/")
+			if members, err := u.ownersRepo.ListTeamMembers(ctx, org, slug); err == nil {
+				teamMembers[e.Reviewer] = members
+			}
+		}
+	}
+	return teamMembers
+}
+
+// loadWindow is the period reviewer load is measured over: the since and until dates when
+// given, otherwise from the first review request of the PRs until Now, or until their last
+// request event without Now
+func loadWindow(opts MeasureOptions, metrics []*entity.ReviewMetrics, teamMembers map[string][]string) (time.Time, time.Time) {
+	var from, to time.Time
+	for _, m := range metrics {
+		for _, r := range m.PullRequest.PendingRequests(teamMembers) {
+			if from.IsZero() || r.RequestedAt.Before(from) {
+				from = r.RequestedAt
+			}
+			if r.RequestedAt.After(to) {
+				to = r.RequestedAt
+			}
+			if r.ResolvedAt != nil && r.ResolvedAt.After(to) {
+				to = *r.ResolvedAt
+			}
+		}
+	}
+
+	if opts.Since != nil {
+		from = *opts.Since
+	}
+	switch {
+	case opts.Until != nil:
+		// The until date is inclusive
+		to = opts.Until.Add(24 * time.Hour)
+	case !opts.Now.IsZero():
+		// Requests nobody answered are still in the queues today
+		to = opts.Now
+	}
+	return from, to
+}
+
+// allPRs is the single group key used when aggregating over all PRs
+const allPRs = "all"
+
+func sizeBucketKeys() []string {
+	keys := make([]string, 0, len(entity.SizeBuckets))
+	for _, b := range entity.SizeBuckets {
+		keys = append(keys, string(b))
+	}
+	return keys
 }
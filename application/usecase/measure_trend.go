@@ -0,0 +1,69 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/dragoneena12/measure-review-time/domain/entity"
+)
+
+// MeasureTrendUseCase reports how the review metrics change over time
+type MeasureTrendUseCase struct {
+	measure *MeasureReviewTimeUseCase
+}
+
+func NewMeasureTrendUseCase(measure *MeasureReviewTimeUseCase) *MeasureTrendUseCase {
+	return &MeasureTrendUseCase{
+		measure: measure,
+	}
+}
+
+// Execute measures the PRs and summarizes them per bucket of the interval, by the given date
+func (u *MeasureTrendUseCase) Execute(ctx context.Context, opts MeasureOptions, interval entity.TrendInterval, by entity.TrendDate) (*entity.TrendReport, error) {
+	report, err := u.measure.Execute(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	trend := &entity.TrendReport{
+		Owner:      report.Owner,
+		Repo:       report.Repo,
+		Interval:   interval,
+		By:         by,
+		Exclusions: report.Exclusions,
+	}
+
+	// Bucket starts are UTC dates without a monotonic reading, so they are usable as keys
+	bucketed := make(map[time.Time][]*entity.ReviewMetrics)
+	var first, last time.Time
+	for _, m := range report.Metrics {
+		date := by.Of(m.PullRequest)
+		if date == nil {
+			trend.Undated++
+			continue
+		}
+
+		start := interval.Start(*date)
+		bucketed[start] = append(bucketed[start], m)
+		if first.IsZero() || start.Before(first) {
+			first = start
+		}
+		if last.IsZero() || start.After(last) {
+			last = start
+		}
+	}
+	if len(bucketed) == 0 {
+		return trend, nil
+	}
+
+	for start := first; !start.After(last); start = interval.Next(start) {
+		metrics, outcome := summarized(bucketed[start], opts.CombineOutcomes)
+		trend.Buckets = append(trend.Buckets, entity.TrendBucket{
+			Start:   start,
+			End:     interval.Next(start),
+			Summary: *summarize(metrics, outcome, opts.excludeOutliers()),
+		})
+	}
+
+	return trend, nil
+}
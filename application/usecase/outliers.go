@@ -0,0 +1,23 @@
+package usecase
+
+import "github.com/dragoneena12/measure-review-time/domain/entity"
+
+// detectOutliers finds the normal range of every duration metric over the PRs, and marks the
+// metrics of each PR falling outside of it
+func detectOutliers(metrics []*entity.ReviewMetrics, method entity.OutlierMethod) []entity.OutlierFence {
+	var fences []entity.OutlierFence
+	for _, metric := range entity.DurationMetrics {
+		fence := method.Fence(metric, metric.Durations(metrics))
+		if fence == nil {
+			continue
+		}
+		fences = append(fences, *fence)
+
+		for _, m := range metrics {
+			if d := metric.Of(m); d != nil && !fence.Contains(*d) {
+				m.Outliers = append(m.Outliers, metric.Key)
+			}
+		}
+	}
+	return fences
+}
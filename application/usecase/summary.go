@@ -0,0 +1,73 @@
+package usecase
+
+import (
+	"time"
+
+	"github.com/dragoneena12/measure-review-time/domain/entity"
+)
+
+// summarized returns the PRs summary statistics are computed over, and their outcome. These
+// are the merged PRs, so that abandoned and still open PRs, often never reviewed, don't mix
+// into the headline numbers; with combineOutcomes, all PRs and an empty outcome.
+func summarized(metrics []*entity.ReviewMetrics, combineOutcomes bool) ([]*entity.ReviewMetrics, entity.Outcome) {
+	if combineOutcomes {
+		return metrics, ""
+	}
+	var merged []*entity.ReviewMetrics
+	for _, m := range metrics {
+		if m.Outcome == entity.OutcomeMerged {
+			merged = append(merged, m)
+		}
+	}
+	return merged, entity.OutcomeMerged
+}
+
+// summarize computes the distribution of every duration metric measured for the PRs of the
+// outcome, see summarized, and how many of them were never reviewed or approved. With
+// excludeOutliers, values detected as outliers are left out of the distributions.
+func summarize(metrics []*entity.ReviewMetrics, outcome entity.Outcome, excludeOutliers bool) *entity.Summary {
+	summary := &entity.Summary{Outcome: outcome, PRs: len(metrics)}
+	for _, m := range metrics {
+		if m.TimeToReview == nil {
+			summary.Unreviewed++
+		}
+		if m.TimeToApprove == nil {
+			summary.Unapproved++
+		}
+	}
+
+	for _, metric := range entity.DurationMetrics {
+		durations, outliers := durationsOf(metric, metrics, excludeOutliers)
+		if len(durations) == 0 && outliers == 0 {
+			continue
+		}
+		summary.Metrics = append(summary.Metrics, entity.MetricSummary{
+			Metric:          metric,
+			DurationSummary: entity.Summarize(durations),
+			Outliers:        outliers,
+		})
+	}
+	return summary
+}
+
+// durationsOf collects the metric over the PRs that have it. With excludeOutliers, it leaves
+// out the outliers and returns how many there were.
+func durationsOf(metric entity.DurationMetric, metrics []*entity.ReviewMetrics, excludeOutliers bool) ([]time.Duration, int) {
+	if !excludeOutliers {
+		return metric.Durations(metrics), 0
+	}
+
+	var durations []time.Duration
+	var outliers int
+	for _, m := range metrics {
+		d := metric.Of(m)
+		switch {
+		case d == nil:
+		case m.IsOutlier(metric.Key):
+			outliers++
+		default:
+			durations = append(durations, *d)
+		}
+	}
+	return durations, outliers
+}
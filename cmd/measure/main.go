@@ -6,22 +6,57 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/dragoneena12/measure-review-time/application/usecase"
+	"github.com/dragoneena12/measure-review-time/domain/entity"
 	"github.com/dragoneena12/measure-review-time/domain/repository"
+	"github.com/dragoneena12/measure-review-time/infra/codeowners"
+	"github.com/dragoneena12/measure-review-time/infra/config"
 	"github.com/dragoneena12/measure-review-time/infra/github"
 	"github.com/dragoneena12/measure-review-time/infra/printer"
 )
 
 func main() {
 	var (
-		owner  = flag.String("owner", "", "Repository owner (required)")
-		repo   = flag.String("repo", "", "Repository name (required)")
-		since  = flag.String("since", "", "Only PRs created after this date (YYYY-MM-DD)")
-		until  = flag.String("until", "", "Only PRs created before this date (YYYY-MM-DD)")
-		format = flag.String("format", "table", "Output format (table, json, csv)")
-		debug  = flag.Bool("debug", false, "Enable debug logging")
+		owner          = flag.String("owner", "", "Repository owner (required)")
+		repo           = flag.String("repo", "", "Repository name (required)")
+		since          = flag.String("since", "", "Only PRs created after this date (YYYY-MM-DD)")
+		until          = flag.String("until", "", "Only PRs created before this date (YYYY-MM-DD)")
+		format         = flag.String("format", "table", "Output format (table, json, csv)")
+		mode           = flag.String("mode", "report", "Report mode (report: closed PRs, open: review debt of open PRs, trend: metrics over time, compare: two groups of PRs)")
+		trendInterval  = flag.String("trend-interval", "week", "Bucket size in trend mode (day, week, month)")
+		trendBy        = flag.String("trend-by", "created", "Date that decides the bucket of a PR in trend mode (created, merged)")
+		compareBy      = flag.String("compare-by", "period", "What tells the groups apart in compare mode (period, author, label, size)")
+		baselineGroup  = flag.String("baseline", "", "Authors, labels or sizes of the baseline group in compare mode (comma-separated)")
+		currentGroup   = flag.String("current", "", "Authors, labels or sizes of the current group in compare mode (comma-separated)")
+		baselineSince  = flag.String("baseline-since", "", "Start of the baseline period when comparing periods (YYYY-MM-DD, required with -since)")
+		baselineUntil  = flag.String("baseline-until", "", "End of the baseline period when comparing periods (YYYY-MM-DD, before -since)")
+		state          = flag.String("state", "closed", "PR state in report mode (closed, open, all)")
+		combine        = flag.Bool("combine-outcomes", false, "Aggregate merged, closed and open PRs together instead of per outcome")
+		cfg            = flag.String("config", "", "Path to a JSON config file (filters)")
+		labels         = flag.String("label", "", "Only PRs with all of these labels (comma-separated)")
+		base           = flag.String("base", "", "Only PRs targeting this base branch")
+		author         = flag.String("author", "", "Only PRs by these authors (comma-separated)")
+		paths          = flag.String("path", "", "Only PRs touching files matching these globs, e.g. services/payments/** (comma-separated)")
+		codeOwners     = flag.Bool("codeowners", false, "Aggregate review time per CODEOWNERS area, using the file on the default branch")
+		codeOwnersFile = flag.String("codeowners-file", "", "Aggregate review time per CODEOWNERS area, using a local CODEOWNERS file")
+		required       = flag.Int("required-approvals", 0, "Number of distinct approvals required to merge (default: config, branch protection or 1)")
+		codingTime     = flag.Bool("coding-time", false, "Measure coding time from the first commit to PR creation (fetches the commits of each PR)")
+		maxCommitAge   = flag.Duration("max-commit-age", 0, "Ignore commits authored longer than this before PR creation, e.g. 720h (0: no limit)")
+		deployEnv      = flag.String("deploy-environment", "", "Measure lead time to the first successful deployment to this environment (Deployments API)")
+		deployReleases = flag.Bool("deploy-releases", false, "Measure lead time to the first release whose tag contains the merge commit")
+		rework         = flag.Bool("rework", false, "Measure commits pushed after the first review, per team configured in the config file")
+		reviewerLoad   = flag.Bool("reviewer-load", false, "Report pending review requests per reviewer over time and how load relates to response time")
+		rubberStamp    = flag.Bool("rubber-stamp", false, "Flag fast approvals on large PRs and approvals without comments (rule tunable in the config file)")
+		ballInCourt    = flag.Bool("ball-in-court", false, "Split open time into waiting on reviewers, on the author and idle after approval, per team")
+		authorResponse = flag.Bool("author-response", false, "Measure how quickly authors push or reply after CHANGES_REQUESTED or COMMENTED reviews")
+		survival       = flag.Bool("survival", false, "Estimate the share of PRs still waiting for review and approval over time, counting unreviewed PRs as censored")
+		outliers       = flag.String("outliers", "", "Detect outliers of every duration metric (iqr: IQR fences, zscore: robust z-scores)")
+		excludeOutlier = flag.Bool("exclude-outliers", false, "Leave detected outliers out of summary statistics, still listing them (default method: iqr)")
+		fromProtection = flag.Bool("approvals-from-protection", false, "Read the required approval count from branch protection rules")
+		debug          = flag.Bool("debug", false, "Enable debug logging")
 	)
 
 	flag.StringVar(owner, "o", "", "Repository owner (short)")
@@ -53,18 +88,106 @@ func main() {
 	if *debug {
 		logLevel = slog.LevelDebug
 	}
-	
+
 	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{
 		Level: logLevel,
 	})).With("component", "github_client")
 
 	ghClient := github.NewClient(token, logger)
-	measureUseCase := usecase.NewMeasureReviewTimeUseCase(ghClient)
+	measureUseCase := usecase.NewMeasureReviewTimeUseCase(ghClient, ghClient, ghClient, ghClient)
+
+	if *deployEnv != "" && *deployReleases {
+		fmt.Fprintf(os.Stderr, "Error: Use either -deploy-environment or -deploy-releases\n")
+		os.Exit(1)
+	}
+
+	switch *mode {
+	case "report", "open", "trend", "compare":
+	default:
+		fmt.Fprintf(os.Stderr, "Error: Invalid mode %q. Use report, open, trend or compare\n", *mode)
+		os.Exit(1)
+	}
+
+	switch *format {
+	case "table", "json", "csv":
+	default:
+		fmt.Fprintf(os.Stderr, "Error: Invalid format %q. Use table, json or csv\n", *format)
+		os.Exit(1)
+	}
+
+	switch *state {
+	case "closed", "open":
+	case "all":
+		*state = ""
+	default:
+		fmt.Fprintf(os.Stderr, "Error: Invalid state %q. Use closed, open or all\n", *state)
+		os.Exit(1)
+	}
+
+	interval := entity.TrendInterval(*trendInterval)
+	switch interval {
+	case entity.TrendDay, entity.TrendWeek, entity.TrendMonth:
+	default:
+		fmt.Fprintf(os.Stderr, "Error: Invalid trend interval %q. Use day, week or month\n", *trendInterval)
+		os.Exit(1)
+	}
+
+	by := entity.TrendDate(*trendBy)
+	switch by {
+	case entity.TrendByCreated, entity.TrendByMerged:
+	default:
+		fmt.Fprintf(os.Stderr, "Error: Invalid trend date %q. Use created or merged\n", *trendBy)
+		os.Exit(1)
+	}
+
+	outlierMethod := entity.OutlierMethod(*outliers)
+	switch outlierMethod {
+	case entity.OutlierIQR, entity.OutlierRobustZ:
+	case "":
+		if *excludeOutlier {
+			outlierMethod = entity.OutlierIQR
+		}
+	default:
+		fmt.Fprintf(os.Stderr, "Error: Invalid outlier method %q. Use iqr or zscore\n", *outliers)
+		os.Exit(1)
+	}
 
 	opts := usecase.MeasureOptions{
 		Owner: *owner,
 		Repo:  *repo,
-		State: "closed",
+		State: *state,
+		Sizes: entity.DefaultSizeThresholds,
+
+		CombineOutcomes: *combine,
+		CodingTime:      *codingTime || *maxCommitAge > 0,
+		MaxCommitAge:    *maxCommitAge,
+
+		DeployEnvironment:  *deployEnv,
+		DeployFromReleases: *deployReleases,
+
+		Rework:       *rework,
+		ReviewerLoad: *reviewerLoad,
+		BallInCourt:  *ballInCourt,
+		Now:          time.Now(),
+
+		AuthorResponse: *authorResponse,
+		Survival:       *survival,
+
+		Outliers:        outlierMethod,
+		ExcludeOutliers: *excludeOutlier,
+
+		Labels:  splitList(*labels),
+		Base:    *base,
+		Authors: splitList(*author),
+	}
+
+	for _, p := range splitList(*paths) {
+		glob, err := entity.CompilePathGlob(p)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: Invalid path pattern %q: %v\n", p, err)
+			os.Exit(1)
+		}
+		opts.Paths = append(opts.Paths, glob)
 	}
 
 	if *since != "" {
@@ -85,12 +208,101 @@ func main() {
 		opts.Until = &t
 	}
 
-	metrics, err := measureUseCase.Execute(ctx, opts)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+	dimension := entity.ComparisonDimension(*compareBy)
+	switch dimension {
+	case entity.CompareByPeriod, entity.CompareByAuthor, entity.CompareByLabel, entity.CompareBySize:
+	default:
+		fmt.Fprintf(os.Stderr, "Error: Invalid comparison %q. Use period, author, label or size\n", *compareBy)
+		os.Exit(1)
+	}
+	if *mode == "compare" && dimension != entity.CompareByPeriod && (*baselineGroup == "" || *currentGroup == "") {
+		fmt.Fprintf(os.Stderr, "Error: Comparing by %s requires -baseline and -current\n", dimension)
 		os.Exit(1)
 	}
 
+	var baseline entity.Period
+	if *mode == "compare" && dimension == entity.CompareByPeriod {
+		// Without -since the current period would span the whole history, baseline included
+		if *baselineSince == "" || opts.Since == nil {
+			fmt.Fprintf(os.Stderr, "Error: Comparing periods requires -since and -baseline-since\n")
+			os.Exit(1)
+		}
+		t, err := time.Parse("2006-01-02", *baselineSince)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: Invalid baseline-since date format. Use YYYY-MM-DD\n")
+			os.Exit(1)
+		}
+		baseline.Since = &t
+
+		if *baselineUntil != "" {
+			t, err := time.Parse("2006-01-02", *baselineUntil)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: Invalid baseline-until date format. Use YYYY-MM-DD\n")
+				os.Exit(1)
+			}
+			baseline.Until = &t
+		}
+
+		current := entity.Period{Since: opts.Since, Until: opts.Until}
+		if baseline.Overlaps(current) {
+			fmt.Fprintf(os.Stderr, "Error: The baseline period %s overlaps the current period %s. Set -baseline-until before -since\n", baseline, current)
+			os.Exit(1)
+		}
+	}
+
+	if *codeOwnersFile != "" {
+		co, err := codeowners.Load(*codeOwnersFile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		opts.CodeOwners = co
+	}
+	opts.FetchCodeOwners = *codeOwners
+	opts.ApprovalsFromProtection = *fromProtection
+
+	if *cfg != "" {
+		c, err := config.Load(*cfg)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		filter, err := c.AccountFilter()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		opts.Filter = filter
+
+		sizes, err := c.SizeThresholds()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		opts.Sizes = sizes
+
+		opts.RequiredApprovals = c.RequiredApprovals[*owner+"/"+*repo]
+		opts.Teams = c.Teams
+
+		if *rubberStamp {
+			rule, err := c.RubberStampRule()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			opts.RubberStamps = &rule
+		}
+	}
+
+	if *rubberStamp && opts.RubberStamps == nil {
+		rule := entity.DefaultRubberStampRule
+		opts.RubberStamps = &rule
+	}
+
+	if *required > 0 {
+		opts.RequiredApprovals = *required
+	}
+
 	var p repository.Printer
 	switch *format {
 	case "json":
@@ -101,8 +313,60 @@ func main() {
 		p = printer.NewTablePrinter()
 	}
 
-	if err := p.Print(*owner, *repo, metrics); err != nil {
+	switch *mode {
+	case "open":
+		report, err := usecase.NewMeasureOpenPRsUseCase(measureUseCase).Execute(ctx, opts, time.Now())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		printed(p.PrintOpenPRs(report))
+	case "trend":
+		report, err := usecase.NewMeasureTrendUseCase(measureUseCase).Execute(ctx, opts, interval, by)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		printed(p.PrintTrend(report))
+	case "compare":
+		compareUseCase := usecase.NewMeasureComparisonUseCase(measureUseCase)
+		var report *entity.ComparisonReport
+		var err error
+		if dimension == entity.CompareByPeriod {
+			report, err = compareUseCase.Execute(ctx, opts, baseline)
+		} else {
+			report, err = compareUseCase.ExecuteGroups(ctx, opts, dimension, splitList(*baselineGroup), splitList(*currentGroup))
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		printed(p.PrintComparison(report))
+	case "report":
+		report, err := measureUseCase.Execute(ctx, opts)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		printed(p.Print(report))
+	}
+}
+
+// printed exits when printing the result failed
+func printed(err error) {
+	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error printing result: %v\n", err)
 		os.Exit(1)
 	}
-}
\ No newline at end of file
+}
+
+// splitList splits a comma-separated flag value, dropping empty items
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
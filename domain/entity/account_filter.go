@@ -0,0 +1,130 @@
+package entity
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+)
+
+// AccountFilter decides which reviewers are counted and which PR authors are included.
+// A nil filter keeps the default behaviour of ignoring bot reviewers only.
+type AccountFilter struct {
+	// AllowLogins are always counted, even when another rule would exclude them
+	AllowLogins []string
+	// DenyLogins and DenyPatterns exclude reviewers and commenters
+	DenyLogins   []string
+	DenyPatterns []*regexp.Regexp
+	// ExcludeAuthors and ExcludeAuthorPatterns exclude whole PRs by their author
+	ExcludeAuthors        []string
+	ExcludeAuthorPatterns []*regexp.Regexp
+	ExcludeBotAuthors     bool
+	// AutomatedLogins and AutomatedPatterns classify AI/automation reviewers, measured separately from humans
+	AutomatedLogins   []string
+	AutomatedPatterns []*regexp.Regexp
+
+	excluded map[string]int
+}
+
+// Exclusion is the number of items a single filter rule excluded
+type Exclusion struct {
+	Rule  string
+	Count int
+}
+
+// ReviewerExclusion returns the rule excluding the reviewer, or an empty string if the reviewer counts
+func (f *AccountFilter) ReviewerExclusion(login string, isBot bool) string {
+	if f == nil {
+		if isBot {
+			return "reviewer: bot account"
+		}
+		return ""
+	}
+	if slices.Contains(f.AllowLogins, login) {
+		return ""
+	}
+	if slices.Contains(f.DenyLogins, login) {
+		return fmt.Sprintf("reviewer: deny login %s", login)
+	}
+	for _, pattern := range f.DenyPatterns {
+		if pattern.MatchString(login) {
+			return fmt.Sprintf("reviewer: deny pattern %s", pattern)
+		}
+	}
+	if isBot {
+		return "reviewer: bot account"
+	}
+	return ""
+}
+
+// IsAutomatedReviewer reports whether the reviewer is classified as an automated reviewer
+func (f *AccountFilter) IsAutomatedReviewer(login string) bool {
+	if f == nil {
+		return false
+	}
+	if slices.Contains(f.AutomatedLogins, login) {
+		return true
+	}
+	for _, pattern := range f.AutomatedPatterns {
+		if pattern.MatchString(login) {
+			return true
+		}
+	}
+	return false
+}
+
+// AuthorExclusion returns the rule excluding a PR by its author, or an empty string if the PR is included
+func (f *AccountFilter) AuthorExclusion(login string, isBot bool) string {
+	if f == nil || slices.Contains(f.AllowLogins, login) {
+		return ""
+	}
+	if slices.Contains(f.ExcludeAuthors, login) {
+		return fmt.Sprintf("author: exclude login %s", login)
+	}
+	for _, pattern := range f.ExcludeAuthorPatterns {
+		if pattern.MatchString(login) {
+			return fmt.Sprintf("author: exclude pattern %s", pattern)
+		}
+	}
+	if f.ExcludeBotAuthors && isBot {
+		return "author: bot account"
+	}
+	return ""
+}
+
+// Record counts an item excluded by the rule
+func (f *AccountFilter) Record(rule string) {
+	if f == nil || rule == "" {
+		return
+	}
+	if f.excluded == nil {
+		f.excluded = make(map[string]int)
+	}
+	f.excluded[rule]++
+}
+
+// Fresh returns a filter with the same rules and no exclusions recorded yet, to count
+// the exclusions of a separate measurement on their own
+func (f *AccountFilter) Fresh() *AccountFilter {
+	if f == nil {
+		return nil
+	}
+	fresh := *f
+	fresh.excluded = nil
+	return &fresh
+}
+
+// Exclusions returns the recorded exclusion counts sorted by rule
+func (f *AccountFilter) Exclusions() []Exclusion {
+	if f == nil {
+		return nil
+	}
+	exclusions := make([]Exclusion, 0, len(f.excluded))
+	for rule, count := range f.excluded {
+		exclusions = append(exclusions, Exclusion{Rule: rule, Count: count})
+	}
+	sort.Slice(exclusions, func(i, j int) bool {
+		return exclusions[i].Rule < exclusions[j].Rule
+	})
+	return exclusions
+}
@@ -0,0 +1,119 @@
+package entity
+
+import (
+	"sort"
+	"time"
+)
+
+type ReviewStage string
+
+const (
+	StageAwaitingReview   ReviewStage = "awaiting_review"
+	StageAwaitingApproval ReviewStage = "awaiting_approval"
+	StageApproved         ReviewStage = "approved"
+)
+
+// OpenPullRequest is an open PR with how long it has been waiting on reviewers so far
+type OpenPullRequest struct {
+	Metrics *ReviewMetrics
+	Stage   ReviewStage
+	// Waiting is the time since the review request (or creation) for PRs not yet approved
+	Waiting time.Duration
+}
+
+// ReviewerDebt is the open review requests of a single reviewer
+type ReviewerDebt struct {
+	Reviewer    string
+	Requested   int
+	LongestWait time.Duration
+}
+
+// ReviewDebt totals how much review work is currently outstanding
+type ReviewDebt struct {
+	OpenPRs          int
+	AwaitingReview   int
+	AwaitingApproval int
+	Approved         int
+	// TotalWait sums the waiting time of all PRs not yet approved
+	TotalWait   time.Duration
+	Wait        DurationSummary
+	LongestWait time.Duration
+	ByReviewer  []ReviewerDebt
+}
+
+// AgingReport lists open PRs by how long they have waited, longest first
+type AgingReport struct {
+	Owner        string
+	Repo         string
+	GeneratedAt  time.Time
+	PullRequests []OpenPullRequest
+	Debt         ReviewDebt
+	Exclusions   []Exclusion
+}
+
+func NewAgingReport(report *Report, now time.Time) *AgingReport {
+	aging := &AgingReport{
+		Owner:       report.Owner,
+		Repo:        report.Repo,
+		GeneratedAt: now,
+		Exclusions:  report.Exclusions,
+	}
+
+	var waits []time.Duration
+	reviewers := make(map[string]*ReviewerDebt)
+	for _, m := range report.Metrics {
+		pr := m.PullRequest
+		open := OpenPullRequest{Metrics: m}
+
+		switch {
+		case pr.FirstReviewAt == nil:
+			open.Stage = StageAwaitingReview
+			aging.Debt.AwaitingReview++
+		case pr.RequiredApprovalsAt() == nil:
+			open.Stage = StageAwaitingApproval
+			aging.Debt.AwaitingApproval++
+		default:
+			open.Stage = StageApproved
+			aging.Debt.Approved++
+		}
+
+		if open.Stage != StageApproved {
+			open.Waiting = now.Sub(pr.ReviewBaseTime())
+			waits = append(waits, open.Waiting)
+			aging.Debt.TotalWait += open.Waiting
+			aging.Debt.LongestWait = max(aging.Debt.LongestWait, open.Waiting)
+
+			for _, r := range pr.RequestedReviewers {
+				debt, ok := reviewers[r]
+				if !ok {
+					debt = &ReviewerDebt{Reviewer: r}
+					reviewers[r] = debt
+				}
+				debt.Requested++
+				debt.LongestWait = max(debt.LongestWait, open.Waiting)
+			}
+		}
+
+		aging.PullRequests = append(aging.PullRequests, open)
+	}
+
+	aging.Debt.OpenPRs = len(aging.PullRequests)
+	aging.Debt.Wait = Summarize(waits)
+
+	sort.SliceStable(aging.PullRequests, func(i, j int) bool {
+		return aging.PullRequests[i].Waiting > aging.PullRequests[j].Waiting
+	})
+
+	for _, debt := range reviewers {
+		aging.Debt.ByReviewer = append(aging.Debt.ByReviewer, *debt)
+	}
+	sort.Slice(aging.Debt.ByReviewer, func(i, j int) bool {
+		a, b := aging.Debt.ByReviewer[i], aging.Debt.ByReviewer[j]
+		if a.Requested != b.Requested {
+			return a.Requested > b.Requested
+		}
+		return a.Reviewer < b.Reviewer
+	})
+
+	return aging
+}
@@ -0,0 +1,132 @@
+package entity
+
+import (
+	"sort"
+	"time"
+)
+
+// FeedbackResponse is how the author followed up on a review asking for changes or commenting
+type FeedbackResponse struct {
+	Reviewer   string
+	State      string
+	ReviewedAt time.Time
+	// RespondedAt is the author's next push or reply, nil when the author never responded
+	RespondedAt *time.Time
+}
+
+// ResponseTime returns the time until the author responded, or nil without a response
+func (f FeedbackResponse) ResponseTime() *time.Duration {
+	if f.RespondedAt == nil {
+		return nil
+	}
+	d := f.RespondedAt.Sub(f.ReviewedAt)
+	return &d
+}
+
+// FeedbackResponses returns each CHANGES_REQUESTED or COMMENTED review by someone other than
+// the author, with the author's next push, comment or review reply after it.
+// Pushes are only known when the commits were fetched.
+func (pr *PullRequest) FeedbackResponses() []FeedbackResponse {
+	var activity []time.Time
+	for _, c := range pr.CommitLog {
+		activity = append(activity, c.CommittedAt)
+	}
+	for _, c := range pr.Comments {
+		if c.Author == pr.Author {
+			activity = append(activity, c.At)
+		}
+	}
+	for _, r := range pr.Reviews {
+		if r.Reviewer == pr.Author {
+			activity = append(activity, r.At)
+		}
+	}
+	sort.Slice(activity, func(i, j int) bool {
+		return activity[i].Before(activity[j])
+	})
+
+	var responses []FeedbackResponse
+	for _, r := range pr.reviewsInOrder() {
+		if r.Reviewer == pr.Author || (r.State != "CHANGES_REQUESTED" && r.State != "COMMENTED") {
+			continue
+		}
+
+		response := FeedbackResponse{
+			Reviewer:   r.Reviewer,
+			State:      r.State,
+			ReviewedAt: r.At,
+		}
+		i := sort.Search(len(activity), func(i int) bool {
+			return activity[i].After(r.At)
+		})
+		if i < len(activity) {
+			at := activity[i]
+			response.RespondedAt = &at
+		}
+		responses = append(responses, response)
+	}
+	return responses
+}
+
+// AuthorResponsiveness aggregates how quickly authors responded to review feedback
+type AuthorResponsiveness struct {
+	// Author is empty for the whole repository
+	Author     string
+	PRs        int
+	Feedback   int
+	Unanswered int
+	// ResponseTime covers the answered feedback
+	ResponseTime DurationSummary
+}
+
+// GroupAuthorResponses aggregates feedback responses per PR author, slowest median first,
+// and for all authors together. PRs without feedback are left out.
+func GroupAuthorResponses(metrics []*ReviewMetrics) ([]AuthorResponsiveness, AuthorResponsiveness) {
+	type collected struct {
+		responsiveness AuthorResponsiveness
+		durations      []time.Duration
+	}
+
+	add := func(c *collected, responses []FeedbackResponse) {
+		c.responsiveness.PRs++
+		for _, f := range responses {
+			c.responsiveness.Feedback++
+			if d := f.ResponseTime(); d != nil {
+				c.durations = append(c.durations, *d)
+			} else {
+				c.responsiveness.Unanswered++
+			}
+		}
+	}
+
+	all := &collected{}
+	byAuthor := make(map[string]*collected)
+	for _, m := range metrics {
+		if len(m.FeedbackResponses) == 0 {
+			continue
+		}
+		author := m.PullRequest.Author
+		c, ok := byAuthor[author]
+		if !ok {
+			c = &collected{responsiveness: AuthorResponsiveness{Author: author}}
+			byAuthor[author] = c
+		}
+		add(c, m.FeedbackResponses)
+		add(all, m.FeedbackResponses)
+	}
+
+	authors := make([]AuthorResponsiveness, 0, len(byAuthor))
+	for _, c := range byAuthor {
+		c.responsiveness.ResponseTime = Summarize(c.durations)
+		authors = append(authors, c.responsiveness)
+	}
+	sort.Slice(authors, func(i, j int) bool {
+		if authors[i].ResponseTime.Median != authors[j].ResponseTime.Median {
+			return authors[i].ResponseTime.Median > authors[j].ResponseTime.Median
+		}
+		return authors[i].Author < authors[j].Author
+	})
+
+	all.responsiveness.ResponseTime = Summarize(all.durations)
+	return authors, all.responsiveness
+}
@@ -0,0 +1,76 @@
+package entity
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestPullRequestFeedbackResponses(t *testing.T) {
+	pr := &PullRequest{
+		Author: "zed",
+		Reviews: []Review{
+			{Reviewer: "alice", State: "COMMENTED", At: hoursAfter(6)},
+			{Reviewer: "alice", State: "CHANGES_REQUESTED", At: hoursAfter(1)},
+			{Reviewer: "bob", State: "APPROVED", At: hoursAfter(1.5)},
+			// The author's reply to a review thread
+			{Reviewer: "zed", State: "COMMENTED", At: hoursAfter(3)},
+			{Reviewer: "carol", State: "COMMENTED", At: hoursAfter(4)},
+		},
+		// A push at the very time of the review does not answer it
+		CommitLog: []Commit{{CommittedAt: hoursAfter(2)}, {CommittedAt: hoursAfter(6)}},
+		Comments: []Comment{
+			{Author: "alice", At: hoursAfter(4.5)},
+			{Author: "zed", At: hoursAfter(5)},
+		},
+	}
+	want := []FeedbackResponse{
+		{Reviewer: "alice", State: "CHANGES_REQUESTED", ReviewedAt: hoursAfter(1), RespondedAt: ptr(hoursAfter(2))},
+		{Reviewer: "carol", State: "COMMENTED", ReviewedAt: hoursAfter(4), RespondedAt: ptr(hoursAfter(5))},
+		{Reviewer: "alice", State: "COMMENTED", ReviewedAt: hoursAfter(6)},
+	}
+
+	got := pr.FeedbackResponses()
+	if !slices.EqualFunc(got, want, func(a, b FeedbackResponse) bool {
+		return a.Reviewer == b.Reviewer && a.State == b.State && a.ReviewedAt.Equal(b.ReviewedAt) && equalTime(a.RespondedAt, b.RespondedAt)
+	}) {
+		t.Errorf("FeedbackResponses() =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestGroupAuthorResponses(t *testing.T) {
+	responded := func(reviewedAt, respondedAt float64) FeedbackResponse {
+		return FeedbackResponse{ReviewedAt: hoursAfter(reviewedAt), RespondedAt: ptr(hoursAfter(respondedAt))}
+	}
+	metrics := []*ReviewMetrics{
+		{PullRequest: &PullRequest{Author: "zed"}, FeedbackResponses: []FeedbackResponse{responded(0, 1), responded(1, 4)}},
+		{PullRequest: &PullRequest{Author: "zed"}, FeedbackResponses: []FeedbackResponse{{ReviewedAt: hoursAfter(0)}}},
+		{PullRequest: &PullRequest{Author: "yui"}, FeedbackResponses: []FeedbackResponse{responded(0, 5)}},
+		{PullRequest: &PullRequest{Author: "xan"}},
+	}
+
+	authors, all := GroupAuthorResponses(metrics)
+
+	// The slowest median comes first, and authors without feedback are left out
+	want := []struct {
+		author                    string
+		prs, feedback, unanswered int
+		answered                  int
+		median                    time.Duration
+	}{
+		{"yui", 1, 1, 0, 1, 5 * time.Hour},
+		{"zed", 2, 3, 1, 2, 2 * time.Hour},
+		{"", 3, 4, 1, 3, 3 * time.Hour},
+	}
+	got := append(authors, all)
+	if len(got) != len(want) {
+		t.Fatalf("GroupAuthorResponses returned %d authors, want %d: %+v", len(authors), len(want)-1, authors)
+	}
+	for i, w := range want {
+		g := got[i]
+		if g.Author != w.author || g.PRs != w.prs || g.Feedback != w.feedback || g.Unanswered != w.unanswered ||
+			g.ResponseTime.Count != w.answered || g.ResponseTime.Median != w.median {
+			t.Errorf("got %+v, want %+v", g, w)
+		}
+	}
+}
@@ -0,0 +1,197 @@
+package entity
+
+import (
+	"sort"
+	"time"
+)
+
+// Court is whose turn it is to act on a PR
+type Court int
+
+const (
+	// CourtAuthor is waiting for the author: before the review request and after feedback
+	CourtAuthor Court = iota
+	// CourtReviewer is waiting for reviewers to review
+	CourtReviewer
+	// CourtApproved is idle after enough approvals, waiting to be merged
+	CourtApproved
+)
+
+// BallInCourt splits the open time of a PR by whose turn it was
+type BallInCourt struct {
+	ReviewerWait      time.Duration
+	AuthorWait        time.Duration
+	IdleAfterApproval time.Duration
+}
+
+func (b BallInCourt) Total() time.Duration {
+	return b.ReviewerWait + b.AuthorWait + b.IdleAfterApproval
+}
+
+// BallInCourt replays the timeline of the PR until it was closed, or until end while open.
+// The ball goes to the reviewers on the review request (or creation without one), re-requests
+// and author pushes, comments and replies after feedback. It goes to the author on reviews
+// and comments by others, and becomes idle once the required approvals are reached, until
+// a re-request or requested changes.
+// Pushes are only known when the commits were fetched.
+func (pr *PullRequest) BallInCourt(end time.Time) BallInCourt {
+	if pr.ClosedAt != nil {
+		end = *pr.ClosedAt
+	}
+	if pr.MergedAt != nil {
+		end = *pr.MergedAt
+	}
+
+	// Once approved, only a re-request or requested changes take the PR out of the idle state
+	type event struct {
+		at      time.Time
+		court   Court
+		reopens bool
+	}
+
+	events := []event{{at: pr.ReviewBaseTime(), court: CourtReviewer}}
+	for _, e := range pr.ReviewRequests {
+		if !e.Removed {
+			events = append(events, event{at: e.At, court: CourtReviewer, reopens: true})
+		}
+	}
+	for _, c := range pr.CommitLog {
+		events = append(events, event{at: c.CommittedAt, court: CourtReviewer})
+	}
+	for _, c := range pr.Comments {
+		court := CourtAuthor
+		if c.Author == pr.Author {
+			court = CourtReviewer
+		}
+		events = append(events, event{at: c.At, court: court})
+	}
+
+	approvals := make(map[string]bool)
+	required := max(pr.RequiredApprovals, 1)
+	for _, r := range pr.reviewsInOrder() {
+		switch {
+		case r.Reviewer == pr.Author:
+			// Replies to review threads are submitted as reviews by the author
+			events = append(events, event{at: r.At, court: CourtReviewer})
+		case r.State == "APPROVED":
+			approvals[r.Reviewer] = true
+			court := CourtReviewer
+			if len(approvals) >= required {
+				court = CourtApproved
+			}
+			events = append(events, event{at: r.At, court: court})
+		case r.State == "CHANGES_REQUESTED":
+			events = append(events, event{at: r.At, court: CourtAuthor, reopens: true})
+		case r.State == "COMMENTED":
+			events = append(events, event{at: r.At, court: CourtAuthor})
+		default:
+			// DISMISSED reviews were withdrawn and do not hand the PR back to the author
+		}
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].at.Before(events[j].at)
+	})
+
+	// Until the review is requested, the PR is the author's work in progress
+	var result BallInCourt
+	court := CourtAuthor
+	at := pr.CreatedAt
+	base := pr.ReviewBaseTime()
+	for _, e := range events {
+		if e.at.Before(base) {
+			continue
+		}
+		if !e.at.Before(end) {
+			break
+		}
+		if e.court == court || (court == CourtApproved && !e.reopens) {
+			continue
+		}
+		result.add(court, e.at.Sub(at))
+		court, at = e.court, e.at
+	}
+	if end.After(at) {
+		result.add(court, end.Sub(at))
+	}
+
+	return result
+}
+
+func (b *BallInCourt) add(court Court, d time.Duration) {
+	switch court {
+	case CourtReviewer:
+		b.ReviewerWait += d
+	case CourtAuthor:
+		b.AuthorWait += d
+	case CourtApproved:
+		b.IdleAfterApproval += d
+	}
+}
+
+func (pr *PullRequest) reviewsInOrder() []Review {
+	reviews := make([]Review, len(pr.Reviews))
+	copy(reviews, pr.Reviews)
+	sort.SliceStable(reviews, func(i, j int) bool {
+		return reviews[i].At.Before(reviews[j].At)
+	})
+	return reviews
+}
+
+// TeamWait aggregates ball-in-court time of the PRs by the members of a team
+type TeamWait struct {
+	Team              string
+	PRs               int
+	ReviewerWait      DurationSummary
+	AuthorWait        DurationSummary
+	IdleAfterApproval DurationSummary
+	// Total sums each bucket over all PRs, for the share of time
+	Total BallInCourt
+}
+
+// GroupBallInCourt aggregates ball-in-court time per team of the PR author.
+// Teams without measured PRs are omitted.
+func GroupBallInCourt(metrics []*ReviewMetrics, teams Teams) []TeamWait {
+	type durations struct {
+		reviewer, author, idle []time.Duration
+		total                  BallInCourt
+	}
+
+	grouped := make(map[string]*durations)
+	for _, m := range metrics {
+		if m.BallInCourt == nil {
+			continue
+		}
+		b := *m.BallInCourt
+		for _, team := range teams.TeamsOf(m.PullRequest.Author) {
+			d, ok := grouped[team]
+			if !ok {
+				d = &durations{}
+				grouped[team] = d
+			}
+			d.reviewer = append(d.reviewer, b.ReviewerWait)
+			d.author = append(d.author, b.AuthorWait)
+			d.idle = append(d.idle, b.IdleAfterApproval)
+			d.total.ReviewerWait += b.ReviewerWait
+			d.total.AuthorWait += b.AuthorWait
+			d.total.IdleAfterApproval += b.IdleAfterApproval
+		}
+	}
+
+	var groups []TeamWait
+	for _, team := range teams.Names() {
+		d, ok := grouped[team]
+		if !ok {
+			continue
+		}
+		groups = append(groups, TeamWait{
+			Team:              team,
+			PRs:               len(d.reviewer),
+			ReviewerWait:      Summarize(d.reviewer),
+			AuthorWait:        Summarize(d.author),
+			IdleAfterApproval: Summarize(d.idle),
+			Total:             d.total,
+		})
+	}
+	return groups
+}
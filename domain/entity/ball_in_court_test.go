@@ -0,0 +1,90 @@
+package entity
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPullRequestBallInCourt(t *testing.T) {
+	tests := []struct {
+		name string
+		pr   *PullRequest
+		end  time.Time
+		want BallInCourt
+	}{
+		{
+			// Author until the request, reviewer until the comment, author until the push,
+			// reviewer until the approval, then idle until the merge
+			name: "feedback and push",
+			pr: &PullRequest{
+				Author:               "zed",
+				CreatedAt:            hoursAfter(0),
+				FirstReviewRequestAt: ptr(hoursAfter(1)),
+				ReviewRequests:       []ReviewRequestEvent{{Reviewer: "alice", At: hoursAfter(1)}},
+				Reviews: []Review{
+					{Reviewer: "alice", State: "APPROVED", At: hoursAfter(6)},
+					{Reviewer: "alice", State: "COMMENTED", At: hoursAfter(3)},
+				},
+				CommitLog: []Commit{{CommittedAt: hoursAfter(5)}},
+				MergedAt:  ptr(hoursAfter(8)),
+			},
+			end:  hoursAfter(100),
+			want: BallInCourt{ReviewerWait: 3 * time.Hour, AuthorWait: 3 * time.Hour, IdleAfterApproval: 2 * time.Hour},
+		},
+		{
+			name: "dismissed review stays with reviewers",
+			pr: &PullRequest{
+				CreatedAt: hoursAfter(0),
+				Reviews:   []Review{{Reviewer: "bob", State: "DISMISSED", At: hoursAfter(2)}},
+				ClosedAt:  ptr(hoursAfter(4)),
+			},
+			end:  hoursAfter(100),
+			want: BallInCourt{ReviewerWait: 4 * time.Hour},
+		},
+		{
+			// The author's comment after the approval keeps it idle until the re-request
+			name: "idle until re-request while open",
+			pr: &PullRequest{
+				Author:               "zed",
+				CreatedAt:            hoursAfter(0),
+				FirstReviewRequestAt: ptr(hoursAfter(0)),
+				ReviewRequests: []ReviewRequestEvent{
+					{Reviewer: "alice", At: hoursAfter(0)},
+					{Reviewer: "alice", At: hoursAfter(3)},
+				},
+				Reviews: []Review{
+					{Reviewer: "alice", State: "APPROVED", At: hoursAfter(1)},
+					{Reviewer: "alice", State: "APPROVED", At: hoursAfter(5)},
+				},
+				Comments: []Comment{{Author: "zed", At: hoursAfter(2)}},
+			},
+			end:  hoursAfter(6),
+			want: BallInCourt{ReviewerWait: 3 * time.Hour, IdleAfterApproval: 3 * time.Hour},
+		},
+		{
+			name: "requested changes before the required approvals",
+			pr: &PullRequest{
+				Author:            "zed",
+				CreatedAt:         hoursAfter(0),
+				RequiredApprovals: 2,
+				Reviews: []Review{
+					{Reviewer: "alice", State: "APPROVED", At: hoursAfter(1)},
+					{Reviewer: "bob", State: "CHANGES_REQUESTED", At: hoursAfter(2)},
+					// The author replies to the review thread
+					{Reviewer: "zed", State: "COMMENTED", At: hoursAfter(3)},
+					{Reviewer: "bob", State: "APPROVED", At: hoursAfter(4)},
+				},
+				MergedAt: ptr(hoursAfter(5)),
+			},
+			end:  hoursAfter(100),
+			want: BallInCourt{ReviewerWait: 3 * time.Hour, AuthorWait: time.Hour, IdleAfterApproval: time.Hour},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.pr.BallInCourt(tt.end); got != tt.want {
+				t.Errorf("BallInCourt = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
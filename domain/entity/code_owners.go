@@ -0,0 +1,120 @@
+package entity
+
+import (
+	"path"
+	"sort"
+	"strings"
+)
+
+// UnownedArea is the area of files no CODEOWNERS rule matches
+const UnownedArea = "(unowned)"
+
+type CodeOwnersRule struct {
+	Pattern *PathGlob
+	Owners  []string
+}
+
+// Matches reports whether the rule matches the file. As in gitignore, a pattern naming a
+// directory also matches everything below it, while a wildcard in the last segment only
+// matches the files directly there: `docs/*` matches docs/a.md but not docs/build/b.md.
+func (r CodeOwnersRule) Matches(file string) bool {
+	if r.Pattern.Match(file) {
+		return true
+	}
+	if strings.ContainsAny(path.Base(r.Pattern.Pattern), "*?") {
+		return false
+	}
+	for p := path.Dir(file); p != "." && p != "/" && p != ""; p = path.Dir(p) {
+		if r.Pattern.Match(p) {
+			return true
+		}
+	}
+	return false
+}
+
+// CodeOwners is a parsed CODEOWNERS file. Rules are kept in file order.
+type CodeOwners struct {
+	Rules []CodeOwnersRule
+}
+
+// OwnersOf returns the owners of a file. As in GitHub, the last matching rule wins.
+func (c *CodeOwners) OwnersOf(file string) []string {
+	for i := len(c.Rules) - 1; i >= 0; i-- {
+		if c.Rules[i].Matches(file) {
+			return c.Rules[i].Owners
+		}
+	}
+	return nil
+}
+
+// AreasOf returns the owning areas of the files, sorted, with UnownedArea last when some files have no owner
+func (c *CodeOwners) AreasOf(files []string) []string {
+	seen := make(map[string]bool)
+	unowned := false
+	for _, f := range files {
+		owners := c.OwnersOf(f)
+		if len(owners) == 0 {
+			unowned = true
+			continue
+		}
+		for _, o := range owners {
+			seen[o] = true
+		}
+	}
+
+	areas := make([]string, 0, len(seen)+1)
+	for o := range seen {
+		areas = append(areas, o)
+	}
+	sort.Strings(areas)
+	if unowned {
+		areas = append(areas, UnownedArea)
+	}
+	return areas
+}
+
+// IsTeamOwner reports whether the owner refers to a team (@org/team) rather than a user or email
+func IsTeamOwner(owner string) bool {
+	return strings.HasPrefix(owner, "@") && strings.Contains(owner, "/")
+}
+
+// ApprovedByCodeOwner reports whether one of the approvals came from one of the owners.
+// teamMembers maps team owners to their member logins.
+// It returns nil when there are no owners, or when a team without known members leaves it undecided.
+func ApprovedByCodeOwner(approvals []Approval, owners []string, teamMembers map[string][]string) *bool {
+	owned := false
+	undecided := false
+	for _, owner := range owners {
+		if owner == UnownedArea {
+			continue
+		}
+		owned = true
+
+		var logins []string
+		if IsTeamOwner(owner) {
+			members, ok := teamMembers[owner]
+			if !ok {
+				undecided = true
+				continue
+			}
+			logins = members
+		} else {
+			logins = []string{strings.TrimPrefix(owner, "@")}
+		}
+
+		for _, a := range approvals {
+			for _, login := range logins {
+				if strings.EqualFold(a.Reviewer, login) {
+					approved := true
+					return &approved
+				}
+			}
+		}
+	}
+
+	if !owned || undecided {
+		return nil
+	}
+	approved := false
+	return &approved
+}
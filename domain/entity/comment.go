@@ -0,0 +1,61 @@
+package entity
+
+import "time"
+
+// Comment is an issue comment, or an inline review comment on the code
+type Comment struct {
+	Author string
+	At     time.Time
+	Inline bool
+}
+
+// FirstCommentAt returns the earliest comment by someone other than the PR author,
+// ignoring comments before the review request, or nil if there is none
+func (pr *PullRequest) FirstCommentAt() *time.Time {
+	for _, c := range pr.Comments {
+		if c.Author == pr.Author {
+			continue
+		}
+		if pr.FirstReviewRequestAt != nil && c.At.Before(*pr.FirstReviewRequestAt) {
+			continue
+		}
+		at := c.At
+		return &at
+	}
+	return nil
+}
+
+// ReviewDepth is how much a PR was discussed in review, counting the inline review comments
+// and review summaries of everyone but the PR author
+type ReviewDepth struct {
+	Comments   int
+	Commenters int
+	// CommentsPer100Lines is zero for PRs without changed lines
+	CommentsPer100Lines float64
+}
+
+func (pr *PullRequest) ReviewDepth() ReviewDepth {
+	var depth ReviewDepth
+	commenters := make(map[string]bool)
+
+	for _, c := range pr.Comments {
+		if !c.Inline || c.Author == pr.Author {
+			continue
+		}
+		depth.Comments++
+		commenters[c.Author] = true
+	}
+	for _, r := range pr.Reviews {
+		if !r.HasBody || r.Reviewer == pr.Author {
+			continue
+		}
+		depth.Comments++
+		commenters[r.Reviewer] = true
+	}
+
+	depth.Commenters = len(commenters)
+	if lines := pr.ChangedLines(); lines > 0 {
+		depth.CommentsPer100Lines = float64(depth.Comments) * 100 / float64(lines)
+	}
+	return depth
+}
@@ -0,0 +1,46 @@
+package entity
+
+import "time"
+
+// Commit is a commit of a pull request
+type Commit struct {
+	SHA string
+	// AuthoredAt is when the change was written; CommittedAt changes on rebase and amend
+	AuthoredAt  time.Time
+	CommittedAt time.Time
+
+	// Additions and Deletions are only fetched for commits after the first review
+	Additions int
+	Deletions int
+}
+
+// FirstCommitAt returns the earliest authored commit of the PR, or nil if the commits are unknown.
+// With a positive maxAge, commits authored more than maxAge before the PR was opened are ignored,
+// as they usually come from rebased history rather than from the work on the PR.
+func (pr *PullRequest) FirstCommitAt(maxAge time.Duration) *time.Time {
+	var first *time.Time
+	for _, c := range pr.CommitLog {
+		if maxAge > 0 && pr.CreatedAt.Sub(c.AuthoredAt) > maxAge {
+			continue
+		}
+		if first == nil || c.AuthoredAt.Before(*first) {
+			at := c.AuthoredAt
+			first = &at
+		}
+	}
+	return first
+}
+
+// CodingTime is the time from the first commit until the PR was opened, or nil if unknown
+func (pr *PullRequest) CodingTime(maxAge time.Duration) *time.Duration {
+	first := pr.FirstCommitAt(maxAge)
+	if first == nil {
+		return nil
+	}
+	duration := pr.CreatedAt.Sub(*first)
+	if duration < 0 {
+		// Commits pushed after opening the PR have no coding time before it
+		duration = 0
+	}
+	return &duration
+}
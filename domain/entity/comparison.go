@@ -0,0 +1,150 @@
+package entity
+
+import (
+	"slices"
+	"time"
+)
+
+// Period is a range of PR creation dates; nil ends are open
+type Period struct {
+	Since *time.Time
+	Until *time.Time
+}
+
+// String formats the period as since..until, leaving open ends empty
+func (p Period) String() string {
+	var since, until string
+	if p.Since != nil {
+		since = p.Since.Format("2006-01-02")
+	}
+	if p.Until != nil {
+		until = p.Until.Format("2006-01-02")
+	}
+	return since + ".." + until
+}
+
+// Overlaps tells whether the periods share a day. Both ends are inclusive dates, and open
+// ends extend without limit.
+func (p Period) Overlaps(other Period) bool {
+	endsBefore := func(until, since *time.Time) bool {
+		return until != nil && since != nil && until.Before(*since)
+	}
+	return !endsBefore(p.Until, other.Since) && !endsBefore(other.Until, p.Since)
+}
+
+// ComparisonDimension is what tells the compared groups of PRs apart
+type ComparisonDimension string
+
+const (
+	CompareByPeriod ComparisonDimension = "period"
+	CompareByAuthor ComparisonDimension = "author"
+	CompareByLabel  ComparisonDimension = "label"
+	CompareBySize   ComparisonDimension = "size"
+)
+
+// Matches tells whether the PR belongs to the group given by its values along the dimension,
+// such as the authors of the group. A PR with labels of both groups matches both.
+// Periods are measured separately, so no PR matches them.
+func (d ComparisonDimension) Matches(m *ReviewMetrics, values []string) bool {
+	switch d {
+	case CompareByAuthor:
+		return slices.Contains(values, m.PullRequest.Author)
+	case CompareByLabel:
+		return slices.ContainsFunc(m.PullRequest.Labels, func(label string) bool {
+			return slices.Contains(values, label)
+		})
+	case CompareBySize:
+		return slices.Contains(values, string(m.Size))
+	default:
+		return false
+	}
+}
+
+// Statistic is one statistic of a duration summary, such as the median
+type Statistic struct {
+	Key  string
+	Name string
+	Of   func(DurationSummary) time.Duration
+}
+
+// ComparedStatistics are the statistics shown side by side when comparing groups
+var ComparedStatistics = []Statistic{
+	{Key: "mean", Name: "Mean", Of: func(s DurationSummary) time.Duration { return s.Mean }},
+	{Key: "median", Name: "Median", Of: func(s DurationSummary) time.Duration { return s.Median }},
+	{Key: "p75", Name: "P75", Of: func(s DurationSummary) time.Duration { return s.P75 }},
+	{Key: "p90", Name: "P90", Of: func(s DurationSummary) time.Duration { return s.P90 }},
+	{Key: "p95", Name: "P95", Of: func(s DurationSummary) time.Duration { return s.P95 }},
+}
+
+// ConfidenceInterval is an interval estimate of a duration at the given confidence level
+type ConfidenceInterval struct {
+	Lower time.Duration
+	Upper time.Duration
+	Level float64
+}
+
+// ExcludesZero tells whether the interval lies entirely on one side of zero,
+// i.e. the difference is unlikely to be noise
+func (c ConfidenceInterval) ExcludesZero() bool {
+	return c.Lower > 0 || c.Upper < 0
+}
+
+// ComparisonGroup is one side of a comparison
+type ComparisonGroup struct {
+	Label   string
+	Summary Summary
+	// Exclusions are the filter counts of the measurement of this group alone, when the
+	// groups are measured separately as periods are
+	Exclusions []Exclusion
+}
+
+// MetricComparison compares a duration metric between the baseline and the current group
+type MetricComparison struct {
+	Metric   DurationMetric
+	Baseline DurationSummary
+	Current  DurationSummary
+	// MedianDifference estimates the current median minus the baseline median, and is nil
+	// when either group has too few values
+	MedianDifference *ConfidenceInterval
+	// Test tells whether current values tend to differ, with the p-value adjusted over all
+	// compared metrics. It is nil when either group has no values.
+	Test *RankTest
+}
+
+// Significant tells whether the adjusted p-value of the test is below the level
+func (c MetricComparison) Significant(level float64) bool {
+	return c.Test != nil && c.Test.AdjustedPValue < level
+}
+
+// Delta returns the change of the statistic from the baseline to the current group, and the
+// change relative to the baseline in percent. The relative change is nil when either group
+// has no values or the baseline is zero.
+func (c MetricComparison) Delta(s Statistic) (time.Duration, *float64) {
+	if c.Baseline.Count == 0 || c.Current.Count == 0 {
+		return 0, nil
+	}
+
+	baseline := s.Of(c.Baseline)
+	delta := s.Of(c.Current) - baseline
+	if baseline == 0 {
+		return delta, nil
+	}
+	relative := float64(delta) * 100 / float64(baseline)
+	return delta, &relative
+}
+
+// ComparisonReport is the result of comparing two groups of PRs, such as two periods
+type ComparisonReport struct {
+	Owner    string
+	Repo     string
+	Baseline ComparisonGroup
+	Current  ComparisonGroup
+	// Overlapping counts the PRs matching both groups, such as PRs with labels of both.
+	// They are left out of both groups.
+	Overlapping int
+	// Metrics holds the duration metrics measured in either group
+	Metrics []MetricComparison
+	// Exclusions are the filter counts of a single measurement split into both groups;
+	// groups measured separately have their own
+	Exclusions []Exclusion
+}
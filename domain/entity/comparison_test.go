@@ -0,0 +1,41 @@
+package entity
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPeriodOverlaps(t *testing.T) {
+	date := func(s string) *time.Time {
+		d, err := time.Parse("2006-01-02", s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &d
+	}
+
+	tests := []struct {
+		name     string
+		baseline Period
+		current  Period
+		want     bool
+	}{
+		{"disjoint", Period{date("2024-01-01"), date("2024-03-31")}, Period{date("2024-04-01"), date("2024-06-30")}, false},
+		{"sharing the last day", Period{date("2024-01-01"), date("2024-04-01")}, Period{date("2024-04-01"), date("2024-06-30")}, true},
+		{"contained", Period{date("2024-02-01"), date("2024-02-28")}, Period{date("2024-01-01"), date("2024-06-30")}, true},
+		{"open-ended baseline", Period{date("2024-01-01"), nil}, Period{date("2024-04-01"), date("2024-06-30")}, true},
+		{"open-ended current", Period{date("2024-01-01"), date("2024-03-31")}, Period{date("2024-04-01"), nil}, false},
+		{"current without start", Period{date("2024-01-01"), date("2024-03-31")}, Period{nil, date("2024-06-30")}, true},
+		{"baseline after current", Period{date("2024-07-01"), date("2024-09-30")}, Period{date("2024-04-01"), date("2024-06-30")}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.baseline.Overlaps(tt.current); got != tt.want {
+				t.Errorf("%s.Overlaps(%s) = %v, want %v", tt.baseline, tt.current, got, tt.want)
+			}
+			if got := tt.current.Overlaps(tt.baseline); got != tt.want {
+				t.Errorf("%s.Overlaps(%s) = %v, want %v", tt.current, tt.baseline, got, tt.want)
+			}
+		})
+	}
+}
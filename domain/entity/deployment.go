@@ -0,0 +1,20 @@
+package entity
+
+import (
+	"sort"
+	"time"
+)
+
+// Deployment is a successful deployment or a published release
+type Deployment struct {
+	// Ref is the deployed commit SHA or the release tag
+	Ref string
+	At  time.Time
+}
+
+// SortDeployments orders deployments from the oldest to the newest
+func SortDeployments(deployments []Deployment) {
+	sort.SliceStable(deployments, func(i, j int) bool {
+		return deployments[i].At.Before(deployments[j].At)
+	})
+}
@@ -0,0 +1,88 @@
+package entity
+
+import "time"
+
+// DurationMetric is one of the per-PR durations, such as time to review
+type DurationMetric struct {
+	// Key identifies the metric in machine readable output, Name in tables
+	Key  string
+	Name string
+	Of   func(*ReviewMetrics) *time.Duration
+}
+
+// DurationMetrics lists the per-PR durations in the order of the report columns
+var DurationMetrics = []DurationMetric{
+	{Key: "coding_time", Name: "Coding Time", Of: func(m *ReviewMetrics) *time.Duration { return m.CodingTime }},
+	{Key: "time_to_request", Name: "Time to Request", Of: func(m *ReviewMetrics) *time.Duration { return m.TimeToRequest }},
+	{Key: "time_to_first_response", Name: "First Response", Of: func(m *ReviewMetrics) *time.Duration { return m.TimeToFirstResponse }},
+	{Key: "time_to_review", Name: "Time to Review", Of: func(m *ReviewMetrics) *time.Duration { return m.TimeToReview }},
+	{Key: "time_to_automated_review", Name: "Automated Review", Of: func(m *ReviewMetrics) *time.Duration { return m.TimeToAutomatedReview }},
+	{Key: "review_to_approve", Name: "Review to Approve", Of: func(m *ReviewMetrics) *time.Duration { return m.ReviewToApprove }},
+	{Key: "time_to_approve", Name: "Time to Approve", Of: func(m *ReviewMetrics) *time.Duration { return m.TimeToApprove }},
+	{Key: "time_to_final_approve", Name: "Final Approve", Of: func(m *ReviewMetrics) *time.Duration { return m.TimeToFinalApprove }},
+	{Key: "time_to_required_approvals", Name: "Required Approvals", Of: func(m *ReviewMetrics) *time.Duration { return m.TimeToRequiredApprovals }},
+	{Key: "approve_to_merge", Name: "Approve to Merge", Of: func(m *ReviewMetrics) *time.Duration { return m.ApproveToMerge }},
+	{Key: "merge_to_deploy", Name: "Merge to Deploy", Of: func(m *ReviewMetrics) *time.Duration { return m.MergeToDeploy }},
+	{Key: "lifetime", Name: "Lifetime", Of: func(m *ReviewMetrics) *time.Duration { return m.TotalDuration }},
+	{Key: "commit_to_deploy", Name: "Commit to Deploy", Of: func(m *ReviewMetrics) *time.Duration { return m.CommitToDeploy }},
+	{Key: "review_to_last_push", Name: "Review to Last Push", Of: func(m *ReviewMetrics) *time.Duration { return m.ReviewToLastPush }},
+	{Key: "reviewer_wait", Name: "Reviewer Wait", Of: func(m *ReviewMetrics) *time.Duration {
+		if m.BallInCourt == nil {
+			return nil
+		}
+		return &m.BallInCourt.ReviewerWait
+	}},
+	{Key: "author_wait", Name: "Author Wait", Of: func(m *ReviewMetrics) *time.Duration {
+		if m.BallInCourt == nil {
+			return nil
+		}
+		return &m.BallInCourt.AuthorWait
+	}},
+	{Key: "idle_after_approval", Name: "Idle After Approval", Of: func(m *ReviewMetrics) *time.Duration {
+		if m.BallInCourt == nil {
+			return nil
+		}
+		return &m.BallInCourt.IdleAfterApproval
+	}},
+}
+
+// durationMetric returns the metric with the key from DurationMetrics
+func durationMetric(key string) DurationMetric {
+	for _, m := range DurationMetrics {
+		if m.Key == key {
+			return m
+		}
+	}
+	panic("unknown duration metric " + key)
+}
+
+// Durations collects the metric over the PRs that have it
+func (d DurationMetric) Durations(metrics []*ReviewMetrics) []time.Duration {
+	var durations []time.Duration
+	for _, m := range metrics {
+		if v := d.Of(m); v != nil {
+			durations = append(durations, *v)
+		}
+	}
+	return durations
+}
+
+// MetricSummary is the distribution of one duration metric
+type MetricSummary struct {
+	Metric DurationMetric
+	DurationSummary
+	// Outliers is the number of values left out of the distribution as outliers
+	Outliers int
+}
+
+// Summary describes the PRs of a report, or of a group of them, at once
+type Summary struct {
+	// Outcome is the outcome of the summarized PRs, empty when all outcomes are combined
+	Outcome Outcome
+	PRs     int
+	// Unreviewed and Unapproved are the PRs that never got a review or an approval
+	Unreviewed int
+	Unapproved int
+	// Metrics holds the duration metrics measured for at least one PR
+	Metrics []MetricSummary
+}
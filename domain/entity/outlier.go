@@ -0,0 +1,82 @@
+package entity
+
+import (
+	"slices"
+	"time"
+)
+
+// OutlierMethod is how the normal range of a duration metric is decided
+type OutlierMethod string
+
+const (
+	// OutlierIQR uses Tukey's fences, 1.5 interquartile ranges beyond the quartiles
+	OutlierIQR OutlierMethod = "iqr"
+	// OutlierRobustZ uses a robust z-score above 3.5, computed from the median and the
+	// median absolute deviation instead of the mean and the standard deviation
+	OutlierRobustZ OutlierMethod = "zscore"
+)
+
+// minOutlierSample is the fewest values outliers are looked for in
+const minOutlierSample = 4
+
+// OutlierFence is the normal range of a duration metric; values outside it are outliers
+type OutlierFence struct {
+	Metric DurationMetric
+	Lower  time.Duration
+	Upper  time.Duration
+}
+
+// Contains tells whether the value is within the fence
+func (f OutlierFence) Contains(d time.Duration) bool {
+	return d >= f.Lower && d <= f.Upper
+}
+
+// Fence returns the normal range of the durations, or nil when there are too few of them
+// or they are too uniform to tell outliers apart
+func (m OutlierMethod) Fence(metric DurationMetric, durations []time.Duration) *OutlierFence {
+	if len(durations) < minOutlierSample {
+		return nil
+	}
+
+	sorted := slices.Clone(durations)
+	slices.Sort(sorted)
+
+	switch m {
+	case OutlierRobustZ:
+		median := Percentile(sorted, 50)
+		deviations := make([]time.Duration, len(sorted))
+		for i, d := range sorted {
+			deviations[i] = max(d-median, median-d)
+		}
+		slices.Sort(deviations)
+		mad := Percentile(deviations, 50)
+		if mad == 0 {
+			return nil
+		}
+		// 0.6745 scales the MAD to the standard deviation of a normal distribution
+		width := time.Duration(3.5 / 0.6745 * float64(mad))
+		return &OutlierFence{Metric: metric, Lower: median - width, Upper: median + width}
+	default:
+		q1, q3 := Percentile(sorted, 25), Percentile(sorted, 75)
+		iqr := q3 - q1
+		if iqr == 0 {
+			return nil
+		}
+		width := iqr * 3 / 2
+		return &OutlierFence{Metric: metric, Lower: q1 - width, Upper: q3 + width}
+	}
+}
+
+// OutlierDetection is how outliers were detected in a report
+type OutlierDetection struct {
+	Method OutlierMethod
+	// Excluded tells whether outliers were left out of the summary statistics
+	Excluded bool
+	// Fences holds the metrics with enough values to look for outliers in
+	Fences []OutlierFence
+}
+
+// IsOutlier tells whether the metric of the PR was detected as an outlier
+func (m *ReviewMetrics) IsOutlier(key string) bool {
+	return slices.Contains(m.Outliers, key)
+}
@@ -0,0 +1,70 @@
+package entity
+
+import (
+	"testing"
+	"time"
+)
+
+func TestOutlierMethodFence(t *testing.T) {
+	// 3.5 robust z-scores of a MAD of one minute
+	mad := time.Minute
+	robustWidth := time.Duration(3.5 / 0.6745 * float64(mad))
+
+	tests := []struct {
+		name      string
+		method    OutlierMethod
+		durations []time.Duration
+		want      *OutlierFence
+	}{
+		{"iqr below the minimum", OutlierIQR, minutes(1, 2, 100), nil},
+		{
+			// Quartiles 1.75m and 3.25m, 1.5 IQR of 2.25m beyond them
+			name:      "iqr at the minimum",
+			method:    OutlierIQR,
+			durations: minutes(4, 1, 3, 2),
+			want:      &OutlierFence{Lower: -30 * time.Second, Upper: 330 * time.Second},
+		},
+		{"iqr of uniform values", OutlierIQR, minutes(2, 2, 2, 2, 9), nil},
+		{"robust z below the minimum", OutlierRobustZ, minutes(1, 2, 100), nil},
+		{
+			// Median 3m, absolute deviations 0, 1, 1, 2 and 97m
+			name:      "robust z",
+			method:    OutlierRobustZ,
+			durations: minutes(1, 2, 3, 4, 100),
+			want:      &OutlierFence{Lower: 3*time.Minute - robustWidth, Upper: 3*time.Minute + robustWidth},
+		},
+		{"robust z of uniform values", OutlierRobustZ, minutes(5, 5, 5, 100), nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.method.Fence(DurationMetric{Key: "time_to_review"}, tt.durations)
+			if (got == nil) != (tt.want == nil) {
+				t.Fatalf("Fence = %+v, want %+v", deref(got), deref(tt.want))
+			}
+			if got == nil {
+				return
+			}
+			if got.Metric.Key != "time_to_review" || got.Lower != tt.want.Lower || got.Upper != tt.want.Upper {
+				t.Errorf("Fence = [%v, %v] of %s, want [%v, %v] of time_to_review", got.Lower, got.Upper, got.Metric.Key, tt.want.Lower, tt.want.Upper)
+			}
+		})
+	}
+}
+
+func TestOutlierFenceContains(t *testing.T) {
+	fence := OutlierFence{Lower: time.Minute, Upper: time.Hour}
+	tests := []struct {
+		d    time.Duration
+		want bool
+	}{
+		{59 * time.Second, false},
+		{time.Minute, true},
+		{time.Hour, true},
+		{time.Hour + time.Second, false},
+	}
+	for _, tt := range tests {
+		if got := fence.Contains(tt.d); got != tt.want {
+			t.Errorf("Contains(%v) = %v, want %v", tt.d, got, tt.want)
+		}
+	}
+}
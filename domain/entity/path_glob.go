@@ -0,0 +1,70 @@
+package entity
+
+import (
+	"regexp"
+	"strings"
+)
+
+// PathGlob matches repository file paths against a glob pattern.
+// `*` and `?` do not cross directory boundaries, `**` matches any number of directories,
+// and a pattern ending with `/` matches everything below that directory.
+type PathGlob struct {
+	Pattern string
+	re      *regexp.Regexp
+}
+
+func CompilePathGlob(pattern string) (*PathGlob, error) {
+	var b strings.Builder
+	b.WriteString("^")
+
+	glob := strings.TrimPrefix(pattern, "/")
+	if strings.HasSuffix(glob, "/") {
+		glob += "**"
+	}
+
+	for i := 0; i < len(glob); i++ {
+		ch := glob[i]
+		switch ch {
+		case '*':
+			if i+1 < len(glob) && glob[i+1] == '*' {
+				i++
+				// `**/` also matches zero directories
+				if i+1 < len(glob) && glob[i+1] == '/' {
+					i++
+					b.WriteString("(?:.*/)?")
+				} else {
+					b.WriteString(".*")
+				}
+			} else {
+				b.WriteString("[^/]*")
+			}
+		case '?':
+			b.WriteString("[^/]")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(ch)))
+		}
+	}
+	b.WriteString("$")
+
+	re, err := regexp.Compile(b.String())
+	if err != nil {
+		return nil, err
+	}
+	return &PathGlob{Pattern: pattern, re: re}, nil
+}
+
+func (g *PathGlob) Match(path string) bool {
+	return g.re.MatchString(path)
+}
+
+// MatchAnyPath reports whether any of the files matches any of the globs
+func MatchAnyPath(globs []*PathGlob, files []string) bool {
+	for _, f := range files {
+		for _, g := range globs {
+			if g.Match(f) {
+				return true
+			}
+		}
+	}
+	return false
+}
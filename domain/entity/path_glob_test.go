@@ -0,0 +1,44 @@
+package entity
+
+import "testing"
+
+func TestCompilePathGlob(t *testing.T) {
+	tests := []struct {
+		pattern string
+		path    string
+		want    bool
+	}{
+		{"*.go", "main.go", true},
+		{"*.go", "cmd/main.go", false},
+		{"cmd/*.go", "cmd/main.go", true},
+		{"cmd/*.go", "cmd/measure/main.go", false},
+		{"**/*.go", "main.go", true},
+		{"**/*.go", "cmd/measure/main.go", true},
+		{"**/*.go", "main.go.txt", false},
+		{"docs/**", "docs/a.md", true},
+		{"docs/**", "docs/build/a.md", true},
+		{"docs/**", "docs", false},
+		{"docs/", "docs/build/a.md", true},
+		{"docs/", "other/docs/a.md", false},
+		{"/docs/*", "docs/a.md", true},
+		{"a/**/b", "a/b", true},
+		{"a/**/b", "a/x/y/b", true},
+		{"a/**/b", "a/x/c", false},
+		{"file?.txt", "file1.txt", true},
+		{"file?.txt", "file/.txt", false},
+		{"file?.txt", "file12.txt", false},
+		{"a+b.txt", "a+b.txt", true},
+		{"a+b.txt", "aab.txt", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pattern+" "+tt.path, func(t *testing.T) {
+			glob, err := CompilePathGlob(tt.pattern)
+			if err != nil {
+				t.Fatalf("CompilePathGlob(%q) returned error: %v", tt.pattern, err)
+			}
+			if got := glob.Match(tt.path); got != tt.want {
+				t.Errorf("CompilePathGlob(%q).Match(%q) = %v, want %v", tt.pattern, tt.path, got, tt.want)
+			}
+		})
+	}
+}
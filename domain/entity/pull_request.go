@@ -5,55 +5,246 @@ import (
 )
 
 type PullRequest struct {
-	ID                   int64
-	Number               int
-	Title                string
-	Author               string
-	State                string
+	ID         int64
+	Number     int
+	Title      string
+	Author     string
+	State      string
+	BaseBranch string
+	HeadSHA    string
+	// MergeCommitSHA is the commit the PR was merged as
+	MergeCommitSHA string
+	Draft          bool
+	// RequestedReviewers are the users and teams (@org/team) whose review is currently requested
+	RequestedReviewers   []string
+	Labels               []string
 	CreatedAt            time.Time
 	MergedAt             *time.Time
 	ClosedAt             *time.Time
 	FirstReviewRequestAt *time.Time
 	FirstReviewAt        *time.Time
 	FirstApproveAt       *time.Time
-	ReviewDuration       *time.Duration
+	// FinalApproveAt is the approval that enabled the merge: not dismissed, and on the
+	// final commit unless no approval of the final commit exists
+	FinalApproveAt  *time.Time
+	FirstResponseAt *time.Time
+	// FirstAutomatedReviewAt is the first review by an automated (AI) reviewer
+	FirstAutomatedReviewAt *time.Time
+	// DeployedAt is the first deployment or release that contained the merge commit
+	DeployedAt     *time.Time
+	ReviewDuration *time.Duration
+
+	// Size of the change
+	Additions    int
+	Deletions    int
+	ChangedFiles int
+	Commits      int
+
+	// CommitLog are the commits of the PR, only fetched when needed
+	CommitLog []Commit
+
+	// Files are the changed file paths, only fetched when needed
+	Files []string
+
+	// Approvals are the first approval of each distinct reviewer, in order, excluding dismissed ones
+	Approvals []Approval
+	// DismissedApprovals were dismissed later, StaleApprovals were followed by new commits
+	DismissedApprovals int
+	StaleApprovals     int
+	// RequiredApprovals is the number of distinct approvals needed to merge
+	RequiredApprovals int
+
+	// ReviewRequests are the review request and removal events in timeline order
+	ReviewRequests []ReviewRequestEvent
+	// Reviews are the submitted reviews by human reviewers not excluded by the filters
+	Reviews []Review
+	// Comments are the issue and review comments by accounts not excluded by the filters, in time order
+	Comments []Comment
+}
+
+// Approval is an approving review by a non-author, non-bot reviewer
+type Approval struct {
+	Reviewer string
+	At       time.Time
+}
+
+// Review is a submitted review
+type Review struct {
+	Reviewer string
+	// State is APPROVED, CHANGES_REQUESTED, COMMENTED or DISMISSED
+	State string
+	At    time.Time
+	// HasBody tells whether the review was submitted with a summary comment
+	HasBody bool
+}
+
+// Outcome is how a PR ended up
+type Outcome string
+
+const (
+	OutcomeMerged Outcome = "merged"
+	// OutcomeClosed is a PR closed without being merged
+	OutcomeClosed Outcome = "closed"
+	OutcomeOpen   Outcome = "open"
+)
+
+var Outcomes = []Outcome{OutcomeMerged, OutcomeClosed, OutcomeOpen}
+
+func (pr *PullRequest) Outcome() Outcome {
+	switch {
+	case pr.MergedAt != nil:
+		return OutcomeMerged
+	case pr.ClosedAt != nil || pr.State == "closed":
+		return OutcomeClosed
+	default:
+		return OutcomeOpen
+	}
 }
 
 type ReviewMetrics struct {
 	PullRequest   *PullRequest
+	Outcome       Outcome
 	TimeToReview  *time.Duration
 	TimeToApprove *time.Duration
 	TotalDuration *time.Duration
+
+	TimeToFinalApprove *time.Duration
+	// TimeToRequiredApprovals is the time until RequiredApprovals distinct reviewers approved
+	TimeToRequiredApprovals *time.Duration
+
+	// TimeToFirstResponse also counts comments, not only submitted reviews
+	TimeToFirstResponse *time.Duration
+
+	// TimeToAutomatedReview is measured from PR creation, as automated reviewers run on open
+	TimeToAutomatedReview *time.Duration
+
+	// Cycle-time breakdown: open -> request -> first review -> approval -> merge
+	TimeToRequest   *time.Duration
+	ReviewToApprove *time.Duration
+	ApproveToMerge  *time.Duration
+
+	// CodingTime is the time from the first commit until the PR was opened
+	CodingTime *time.Duration
+
+	// MergeToDeploy is the time from merge until the change was deployed, and
+	// CommitToDeploy the lead time for changes from the first commit until deployed
+	MergeToDeploy  *time.Duration
+	CommitToDeploy *time.Duration
+
+	// ReviewDepth is how much the PR was discussed in review
+	ReviewDepth ReviewDepth
+	// FeedbackResponses are the author's responses to review feedback; nil when not measured
+	FeedbackResponses []FeedbackResponse
+	// BallInCourt splits the open time by whose turn it was; nil when not measured
+	BallInCourt *BallInCourt
+	// RubberStamps are the approvals flagged as likely given without a real review
+	RubberStamps []RubberStamp
+
+	// Rework is the work pushed after the first review, and ReviewToLastPush the time from
+	// the first review until the last of those commits; nil when rework is not measured
+	Rework           *Rework
+	ReviewToLastPush *time.Duration
+
+	Size SizeBucket
+
+	// Outliers are the keys of the duration metrics detected as outliers for this PR
+	Outliers []string
+
+	// Areas are the CODEOWNERS owners of the changed files, when CODEOWNERS is used
+	Areas []string
+	// CodeOwnerApproved tells whether an owner of the changed files approved; nil when unknown
+	CodeOwnerApproved *bool
 }
 
-func (pr *PullRequest) CalculateMetrics() *ReviewMetrics {
+func (pr *PullRequest) CalculateMetrics(sizes SizeThresholds) *ReviewMetrics {
 	metrics := &ReviewMetrics{
 		PullRequest: pr,
+		Outcome:     pr.Outcome(),
+		Size:        sizes.Bucket(pr.ChangedLines()),
+		ReviewDepth: pr.ReviewDepth(),
 	}
 
-	// Use FirstReviewRequestAt as baseline if available, otherwise use CreatedAt
-	baseTime := pr.CreatedAt
-	if pr.FirstReviewRequestAt != nil {
-		baseTime = *pr.FirstReviewRequestAt
-	}
+	baseTime := pr.ReviewBaseTime()
 
 	if pr.FirstReviewAt != nil {
 		duration := pr.FirstReviewAt.Sub(baseTime)
 		metrics.TimeToReview = &duration
 	}
 
+	if pr.FirstResponseAt != nil {
+		duration := pr.FirstResponseAt.Sub(baseTime)
+		metrics.TimeToFirstResponse = &duration
+	}
+
+	if pr.FinalApproveAt != nil {
+		duration := pr.FinalApproveAt.Sub(baseTime)
+		metrics.TimeToFinalApprove = &duration
+	}
+
+	if at := pr.RequiredApprovalsAt(); at != nil {
+		duration := at.Sub(baseTime)
+		metrics.TimeToRequiredApprovals = &duration
+	}
+
+	if pr.FirstAutomatedReviewAt != nil {
+		duration := pr.FirstAutomatedReviewAt.Sub(pr.CreatedAt)
+		metrics.TimeToAutomatedReview = &duration
+	}
+
 	if pr.FirstApproveAt != nil {
 		duration := pr.FirstApproveAt.Sub(baseTime)
 		metrics.TimeToApprove = &duration
 	}
 
+	if pr.FirstReviewRequestAt != nil {
+		duration := pr.FirstReviewRequestAt.Sub(pr.CreatedAt)
+		metrics.TimeToRequest = &duration
+	}
+
+	if pr.FirstReviewAt != nil && pr.FirstApproveAt != nil {
+		duration := pr.FirstApproveAt.Sub(*pr.FirstReviewAt)
+		metrics.ReviewToApprove = &duration
+	}
+
+	if pr.FirstApproveAt != nil && pr.MergedAt != nil {
+		duration := pr.MergedAt.Sub(*pr.FirstApproveAt)
+		metrics.ApproveToMerge = &duration
+	}
+
+	if pr.MergedAt != nil && pr.DeployedAt != nil {
+		duration := pr.DeployedAt.Sub(*pr.MergedAt)
+		metrics.MergeToDeploy = &duration
+	}
+
+	// Total lifetime is measured from PR creation, not from the review request.
+	// For PRs closed without merge it ends at closing; Outcome tells the two apart.
 	if pr.MergedAt != nil {
-		duration := pr.MergedAt.Sub(baseTime)
+		duration := pr.MergedAt.Sub(pr.CreatedAt)
 		metrics.TotalDuration = &duration
 	} else if pr.ClosedAt != nil {
-		duration := pr.ClosedAt.Sub(baseTime)
+		duration := pr.ClosedAt.Sub(pr.CreatedAt)
 		metrics.TotalDuration = &duration
 	}
 
 	return metrics
 }
+
+// ReviewBaseTime is the time review latency is measured from:
+// FirstReviewRequestAt if available, otherwise CreatedAt
+func (pr *PullRequest) ReviewBaseTime() time.Time {
+	if pr.FirstReviewRequestAt != nil {
+		return *pr.FirstReviewRequestAt
+	}
+	return pr.CreatedAt
+}
+
+// RequiredApprovalsAt returns when the required number of distinct reviewers had approved,
+// or nil if not enough of them approved. At least one approval is always required.
+func (pr *PullRequest) RequiredApprovalsAt() *time.Time {
+	required := max(pr.RequiredApprovals, 1)
+	if len(pr.Approvals) < required {
+		return nil
+	}
+	at := pr.Approvals[required-1].At
+	return &at
+}
@@ -0,0 +1,50 @@
+package entity
+
+type SizeBucket string
+
+const (
+	SizeXS SizeBucket = "XS"
+	SizeS  SizeBucket = "S"
+	SizeM  SizeBucket = "M"
+	SizeL  SizeBucket = "L"
+	SizeXL SizeBucket = "XL"
+)
+
+// SizeBuckets lists all buckets from smallest to largest
+var SizeBuckets = []SizeBucket{SizeXS, SizeS, SizeM, SizeL, SizeXL}
+
+// SizeThresholds are the inclusive upper bounds of changed lines for each bucket.
+// Anything above L is XL.
+type SizeThresholds struct {
+	XS int
+	S  int
+	M  int
+	L  int
+}
+
+var DefaultSizeThresholds = SizeThresholds{
+	XS: 10,
+	S:  100,
+	M:  500,
+	L:  1000,
+}
+
+func (t SizeThresholds) Bucket(changedLines int) SizeBucket {
+	switch {
+	case changedLines <= t.XS:
+		return SizeXS
+	case changedLines <= t.S:
+		return SizeS
+	case changedLines <= t.M:
+		return SizeM
+	case changedLines <= t.L:
+		return SizeL
+	default:
+		return SizeXL
+	}
+}
+
+// ChangedLines is the number of added and deleted lines
+func (pr *PullRequest) ChangedLines() int {
+	return pr.Additions + pr.Deletions
+}
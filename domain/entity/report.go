@@ -0,0 +1,47 @@
+package entity
+
+// MeasuredMetrics are the per-PR metrics measured only on request, as they take more API calls
+type MeasuredMetrics struct {
+	CodingTime     bool
+	Deployments    bool
+	Rework         bool
+	BallInCourt    bool
+	AuthorResponse bool
+}
+
+// Report is the result of a measurement, handed to printers as a whole
+type Report struct {
+	Owner      string
+	Repo       string
+	Metrics    []*ReviewMetrics
+	Exclusions []Exclusion
+	// Measured tells which of the optional per-PR metrics were measured
+	Measured MeasuredMetrics
+	// Summary is the distribution of every duration metric over all PRs
+	Summary *Summary
+	// Outliers is how outliers were detected, when outlier detection is enabled
+	Outliers *OutlierDetection
+	// Survival estimates the share of PRs still waiting for a review or approval over time,
+	// counting unreviewed PRs as censored, when it is measured
+	Survival []MetricSurvival
+	// Outcomes is review latency of all PRs broken down by merged, closed and open
+	Outcomes []LatencyGroup
+	// SizeBuckets is review latency broken down by PR size
+	SizeBuckets []LatencyGroup
+	// Areas is review latency broken down by CODEOWNERS area, when CODEOWNERS is used
+	Areas []AreaLatency
+	// Rework is the work pushed after the first review per author team, when rework is measured
+	Rework []ReworkGroup
+	// AuthorResponses is how quickly each author responded to review feedback, and
+	// RepoAuthorResponse the same for all authors, when author response is measured
+	AuthorResponses    []AuthorResponsiveness
+	RepoAuthorResponse *AuthorResponsiveness
+	// TeamWaits is the ball-in-court time per author team, when it is measured
+	TeamWaits []TeamWait
+	// ReviewerLoad is the review queue of each reviewer over time, when reviewer load is measured
+	ReviewerLoad []ReviewerLoad
+	// RubberStamps counts flagged approvals per reviewer, when rubber-stamp detection is enabled
+	RubberStamps []RubberStampCount
+	// RubberStampRule is the rule approvals were checked with; nil when detection is disabled
+	RubberStampRule *RubberStampRule
+}
@@ -0,0 +1,117 @@
+package entity
+
+import (
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ReviewRequestEvent is a review request, or its removal, for a user or a team (@org/team)
+type ReviewRequestEvent struct {
+	Reviewer string
+	At       time.Time
+	Removed  bool
+}
+
+// PendingRequest is the period a reviewer had a review request of a PR waiting
+type PendingRequest struct {
+	Reviewer    string
+	RequestedAt time.Time
+	// ResolvedAt is when the reviewer reviewed, the request was removed or the PR was closed;
+	// nil while it is still pending
+	ResolvedAt *time.Time
+	// ReviewedAt is set when the request was resolved by a review of the reviewer
+	ReviewedAt *time.Time
+}
+
+// FirstRequestedAt returns the time of the first review request, or nil if none was made
+func (pr *PullRequest) FirstRequestedAt() *time.Time {
+	var first *time.Time
+	for _, e := range pr.ReviewRequests {
+		if e.Removed {
+			continue
+		}
+		if first == nil || e.At.Before(*first) {
+			at := e.At
+			first = &at
+		}
+	}
+	return first
+}
+
+// PendingRequests returns the periods each review request of the PR was pending.
+// Submitting a review clears the request, as GitHub does; re-requests start a new period.
+// A review by a member of a requested team clears the request of the team too. Requests of
+// teams missing from teamMembers are left out, as it is unknown who answers for them.
+func (pr *PullRequest) PendingRequests(teamMembers map[string][]string) []PendingRequest {
+	type event struct {
+		at       time.Time
+		reviewer string
+		kind     int
+	}
+	const (
+		requested = iota
+		removed
+		reviewed
+	)
+
+	events := make([]event, 0, len(pr.ReviewRequests)+len(pr.Reviews))
+	for _, e := range pr.ReviewRequests {
+		if _, known := teamMembers[e.Reviewer]; IsTeamOwner(e.Reviewer) && !known {
+			continue
+		}
+		kind := requested
+		if e.Removed {
+			kind = removed
+		}
+		events = append(events, event{at: e.At, reviewer: e.Reviewer, kind: kind})
+	}
+	for _, r := range pr.Reviews {
+		events = append(events, event{at: r.At, reviewer: r.Reviewer, kind: reviewed})
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].at.Before(events[j].at)
+	})
+
+	var requests []PendingRequest
+	open := make(map[string]int)
+	resolve := func(reviewer string, at time.Time, review bool) {
+		i := open[reviewer]
+		requests[i].ResolvedAt = &at
+		if review {
+			requests[i].ReviewedAt = &at
+		}
+		delete(open, reviewer)
+	}
+	for _, e := range events {
+		_, pending := open[e.reviewer]
+		switch {
+		case e.kind == requested && !pending:
+			open[e.reviewer] = len(requests)
+			requests = append(requests, PendingRequest{Reviewer: e.reviewer, RequestedAt: e.at})
+		case e.kind != requested && pending:
+			resolve(e.reviewer, e.at, e.kind == reviewed)
+		}
+
+		if e.kind == reviewed {
+			for team := range open {
+				if IsTeamOwner(team) && slices.ContainsFunc(teamMembers[team], func(member string) bool {
+					return strings.EqualFold(member, e.reviewer)
+				}) {
+					resolve(team, e.at, true)
+				}
+			}
+		}
+	}
+
+	// Requests still pending when the PR was closed are dropped from the queue at closing
+	if pr.ClosedAt != nil {
+		for _, i := range open {
+			at := *pr.ClosedAt
+			requests[i].ResolvedAt = &at
+		}
+	}
+
+	return requests
+}
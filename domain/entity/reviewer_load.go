@@ -0,0 +1,179 @@
+package entity
+
+import (
+	"sort"
+	"time"
+)
+
+// DailyLoad is the review queue of a reviewer on a day (UTC)
+type DailyLoad struct {
+	Date time.Time
+	Max  int
+	// Average is the queue depth averaged over the time of the day within the window
+	Average float64
+}
+
+// ReviewerLoad is how many review requests a reviewer had pending over the window
+type ReviewerLoad struct {
+	Reviewer string
+	// Requests is the number of review requests made to the reviewer in the window
+	Requests     int
+	MaxQueue     int
+	AverageQueue float64
+	Daily        []DailyLoad
+	// ResponseTime is the time from a request until the reviewer reviewed
+	ResponseTime DurationSummary
+	// LoadCorrelation is the rank correlation between the queue depth when a request was made
+	// and the time until the reviewer responded to it; nil with fewer than three responses
+	LoadCorrelation *float64
+}
+
+// NewReviewerLoads computes the review queue of every requested reviewer between from and to,
+// busiest reviewers first. Requests still pending are counted until to. Teams are only
+// included when their members are known, see PendingRequests.
+func NewReviewerLoads(metrics []*ReviewMetrics, teamMembers map[string][]string, from, to time.Time) []ReviewerLoad {
+	if !to.After(from) {
+		return nil
+	}
+
+	byReviewer := make(map[string][]PendingRequest)
+	for _, m := range metrics {
+		for _, r := range m.PullRequest.PendingRequests(teamMembers) {
+			byReviewer[r.Reviewer] = append(byReviewer[r.Reviewer], r)
+		}
+	}
+
+	var loads []ReviewerLoad
+	for reviewer, requests := range byReviewer {
+		queue := newRequestQueue(requests, from, to)
+		if len(queue.intervals) == 0 {
+			continue
+		}
+
+		load := ReviewerLoad{
+			Reviewer:     reviewer,
+			AverageQueue: queue.average(from, to),
+		}
+
+		for day := from.UTC().Truncate(24 * time.Hour); day.Before(to); day = day.Add(24 * time.Hour) {
+			start, end := maxTime(day, from), minTime(day.Add(24*time.Hour), to)
+			daily := DailyLoad{
+				Date:    day,
+				Max:     queue.max(start, end),
+				Average: queue.average(start, end),
+			}
+			load.MaxQueue = max(load.MaxQueue, daily.Max)
+			load.Daily = append(load.Daily, daily)
+		}
+
+		var responses []time.Duration
+		var depths, minutes []float64
+		for _, r := range requests {
+			if r.RequestedAt.Before(from) || !r.RequestedAt.Before(to) {
+				continue
+			}
+			load.Requests++
+			if r.ReviewedAt == nil {
+				continue
+			}
+			response := r.ReviewedAt.Sub(r.RequestedAt)
+			responses = append(responses, response)
+			depths = append(depths, float64(queue.depthAt(r.RequestedAt)))
+			minutes = append(minutes, response.Minutes())
+		}
+		load.ResponseTime = Summarize(responses)
+		load.LoadCorrelation = RankCorrelation(depths, minutes)
+
+		loads = append(loads, load)
+	}
+
+	sort.Slice(loads, func(i, j int) bool {
+		if loads[i].AverageQueue != loads[j].AverageQueue {
+			return loads[i].AverageQueue > loads[j].AverageQueue
+		}
+		return loads[i].Reviewer < loads[j].Reviewer
+	})
+	return loads
+}
+
+type interval struct {
+	start, end time.Time
+}
+
+// requestQueue holds the pending periods of a reviewer's requests, clipped to the window
+type requestQueue struct {
+	intervals []interval
+}
+
+func newRequestQueue(requests []PendingRequest, from, to time.Time) requestQueue {
+	var q requestQueue
+	for _, r := range requests {
+		end := to
+		if r.ResolvedAt != nil && r.ResolvedAt.Before(to) {
+			end = *r.ResolvedAt
+		}
+		start := maxTime(r.RequestedAt, from)
+		if start.Before(end) {
+			q.intervals = append(q.intervals, interval{start: start, end: end})
+		}
+	}
+	return q
+}
+
+func (q requestQueue) depthAt(t time.Time) int {
+	depth := 0
+	for _, iv := range q.intervals {
+		if !t.Before(iv.start) && t.Before(iv.end) {
+			depth++
+		}
+	}
+	return depth
+}
+
+// max is the highest depth between start and end, which is reached at start or when a request starts
+func (q requestQueue) max(start, end time.Time) int {
+	highest := q.depthAt(start)
+	for _, iv := range q.intervals {
+		if iv.start.After(start) && iv.start.Before(end) {
+			highest = max(highest, q.depthAt(iv.start))
+		}
+	}
+	return highest
+}
+
+// average is the time-weighted depth between start and end
+func (q requestQueue) average(start, end time.Time) float64 {
+	var pending time.Duration
+	for _, iv := range q.intervals {
+		if s, e := maxTime(iv.start, start), minTime(iv.end, end); s.Before(e) {
+			pending += e.Sub(s)
+		}
+	}
+	return float64(pending) / float64(end.Sub(start))
+}
+
+func maxTime(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
+
+// MeanDailyMax is the daily maximum queue depth averaged over the days of the window
+func (l ReviewerLoad) MeanDailyMax() float64 {
+	if len(l.Daily) == 0 {
+		return 0
+	}
+	total := 0
+	for _, d := range l.Daily {
+		total += d.Max
+	}
+	return float64(total) / float64(len(l.Daily))
+}
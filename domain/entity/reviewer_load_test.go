@@ -0,0 +1,156 @@
+package entity
+
+import (
+	"math"
+	"slices"
+	"testing"
+	"time"
+)
+
+var loadStart = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func hoursAfter(h float64) time.Time {
+	return loadStart.Add(time.Duration(h * float64(time.Hour)))
+}
+
+func TestPendingRequests(t *testing.T) {
+	tests := []struct {
+		name string
+		pr   *PullRequest
+		want []PendingRequest
+	}{
+		{
+			name: "reviews, re-requests and removals",
+			pr: &PullRequest{
+				Author: "zed",
+				ReviewRequests: []ReviewRequestEvent{
+					{Reviewer: "alice", At: hoursAfter(0)},
+					{Reviewer: "bob", At: hoursAfter(0)},
+					{Reviewer: "@o/core", At: hoursAfter(0)},
+					// Members of @o/web are unknown, so its request is left out
+					{Reviewer: "@o/web", At: hoursAfter(1)},
+					{Reviewer: "alice", At: hoursAfter(4)},
+					{Reviewer: "bob", At: hoursAfter(5), Removed: true},
+				},
+				Reviews: []Review{
+					{Reviewer: "alice", State: "COMMENTED", At: hoursAfter(2)},
+					// A member of @o/core reviews on behalf of the team
+					{Reviewer: "carol", State: "APPROVED", At: hoursAfter(3)},
+				},
+			},
+			want: []PendingRequest{
+				{Reviewer: "alice", RequestedAt: hoursAfter(0), ResolvedAt: ptr(hoursAfter(2)), ReviewedAt: ptr(hoursAfter(2))},
+				{Reviewer: "bob", RequestedAt: hoursAfter(0), ResolvedAt: ptr(hoursAfter(5))},
+				{Reviewer: "@o/core", RequestedAt: hoursAfter(0), ResolvedAt: ptr(hoursAfter(3)), ReviewedAt: ptr(hoursAfter(3))},
+				{Reviewer: "alice", RequestedAt: hoursAfter(4)},
+			},
+		},
+		{
+			name: "request again while pending",
+			pr: &PullRequest{
+				ReviewRequests: []ReviewRequestEvent{
+					{Reviewer: "alice", At: hoursAfter(0)},
+					{Reviewer: "alice", At: hoursAfter(1)},
+				},
+				Reviews: []Review{{Reviewer: "alice", State: "APPROVED", At: hoursAfter(2)}},
+			},
+			want: []PendingRequest{
+				{Reviewer: "alice", RequestedAt: hoursAfter(0), ResolvedAt: ptr(hoursAfter(2)), ReviewedAt: ptr(hoursAfter(2))},
+			},
+		},
+		{
+			name: "review without a request",
+			pr: &PullRequest{
+				Reviews: []Review{{Reviewer: "alice", State: "APPROVED", At: hoursAfter(2)}},
+			},
+			want: nil,
+		},
+		{
+			name: "closed with requests pending",
+			pr: &PullRequest{
+				ReviewRequests: []ReviewRequestEvent{{Reviewer: "dave", At: hoursAfter(0)}},
+				ClosedAt:       ptr(hoursAfter(6)),
+			},
+			want: []PendingRequest{
+				{Reviewer: "dave", RequestedAt: hoursAfter(0), ResolvedAt: ptr(hoursAfter(6))},
+			},
+		},
+	}
+	teamMembers := map[string][]string{"@o/core": {"Carol", "dave"}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.pr.PendingRequests(teamMembers)
+			if !slices.EqualFunc(got, tt.want, func(a, b PendingRequest) bool {
+				return a.Reviewer == b.Reviewer && a.RequestedAt.Equal(b.RequestedAt) &&
+					equalTime(a.ResolvedAt, b.ResolvedAt) && equalTime(a.ReviewedAt, b.ReviewedAt)
+			}) {
+				t.Errorf("PendingRequests() =\n%+v\nwant\n%+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewReviewerLoads(t *testing.T) {
+	metrics := []*ReviewMetrics{
+		// dave answers the first request after 6h, and never the second one
+		{PullRequest: &PullRequest{
+			ReviewRequests: []ReviewRequestEvent{{Reviewer: "dave", At: hoursAfter(0)}, {Reviewer: "erin", At: hoursAfter(0)}},
+			Reviews:        []Review{{Reviewer: "dave", State: "APPROVED", At: hoursAfter(6)}, {Reviewer: "erin", State: "APPROVED", At: hoursAfter(2)}},
+		}},
+		{PullRequest: &PullRequest{
+			ReviewRequests: []ReviewRequestEvent{{Reviewer: "dave", At: hoursAfter(12)}, {Reviewer: "erin", At: hoursAfter(1)}},
+			Reviews:        []Review{{Reviewer: "erin", State: "COMMENTED", At: hoursAfter(2)}},
+		}},
+	}
+
+	loads := NewReviewerLoads(metrics, nil, hoursAfter(0), hoursAfter(48))
+
+	// dave: pending 0-6h and 12-48h; erin: 0-2h and 1-2h
+	want := []struct {
+		reviewer      string
+		requests      int
+		maxQueue      int
+		averageQueue  float64
+		dailyMax      []int
+		dailyAverage  []float64
+		responseCount int
+		medianHours   float64
+	}{
+		{"dave", 2, 1, 42.0 / 48, []int{1, 1}, []float64{18.0 / 24, 1}, 1, 6},
+		{"erin", 2, 2, 3.0 / 48, []int{2, 0}, []float64{3.0 / 24, 0}, 2, 1.5},
+	}
+	if len(loads) != len(want) {
+		t.Fatalf("got %d reviewer loads, want %d: %+v", len(loads), len(want), loads)
+	}
+	for i, w := range want {
+		l := loads[i]
+		if l.Reviewer != w.reviewer {
+			t.Fatalf("loads[%d].Reviewer = %s, want %s", i, l.Reviewer, w.reviewer)
+		}
+		if l.Requests != w.requests || l.MaxQueue != w.maxQueue || math.Abs(l.AverageQueue-w.averageQueue) > 1e-9 {
+			t.Errorf("%s: Requests, MaxQueue, AverageQueue = %d, %d, %v, want %d, %d, %v",
+				w.reviewer, l.Requests, l.MaxQueue, l.AverageQueue, w.requests, w.maxQueue, w.averageQueue)
+		}
+		if len(l.Daily) != len(w.dailyMax) {
+			t.Fatalf("%s: got %d days, want %d", w.reviewer, len(l.Daily), len(w.dailyMax))
+		}
+		for d, daily := range l.Daily {
+			if daily.Max != w.dailyMax[d] || math.Abs(daily.Average-w.dailyAverage[d]) > 1e-9 {
+				t.Errorf("%s day %d: Max, Average = %d, %v, want %d, %v", w.reviewer, d, daily.Max, daily.Average, w.dailyMax[d], w.dailyAverage[d])
+			}
+		}
+		if l.ResponseTime.Count != w.responseCount || l.ResponseTime.Median != time.Duration(w.medianHours*float64(time.Hour)) {
+			t.Errorf("%s: response count, median = %d, %v, want %d, %vh", w.reviewer, l.ResponseTime.Count, l.ResponseTime.Median, w.responseCount, w.medianHours)
+		}
+		if l.LoadCorrelation != nil {
+			t.Errorf("%s: LoadCorrelation = %v, want nil with fewer than three responses", w.reviewer, *l.LoadCorrelation)
+		}
+	}
+}
+
+func equalTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
@@ -0,0 +1,90 @@
+package entity
+
+import "time"
+
+// Rework is the work pushed to a PR after its first review
+type Rework struct {
+	Commits   int
+	Additions int
+	Deletions int
+	// LastPushAt is the last commit after the first review, nil when there is none
+	LastPushAt *time.Time
+}
+
+func (r *Rework) Lines() int {
+	return r.Additions + r.Deletions
+}
+
+// Rework returns the commits made after the first review, or nil when the PR was not
+// reviewed or its commits are unknown. Commit times are committer dates, which are
+// the closest to push times the commit list provides.
+func (pr *PullRequest) Rework() *Rework {
+	if pr.FirstReviewAt == nil || len(pr.CommitLog) == 0 {
+		return nil
+	}
+
+	rework := &Rework{}
+	for _, c := range pr.CommitLog {
+		if !c.CommittedAt.After(*pr.FirstReviewAt) {
+			continue
+		}
+		rework.Commits++
+		rework.Additions += c.Additions
+		rework.Deletions += c.Deletions
+		if rework.LastPushAt == nil || c.CommittedAt.After(*rework.LastPushAt) {
+			at := c.CommittedAt
+			rework.LastPushAt = &at
+		}
+	}
+	return rework
+}
+
+// ReworkGroup aggregates rework of the reviewed PRs by the members of a team
+type ReworkGroup struct {
+	Team string
+	// PRs is the number of reviewed PRs, Reworked those with commits after the first review
+	PRs              int
+	Reworked         int
+	Commits          int
+	Lines            int
+	ReviewToLastPush DurationSummary
+}
+
+// GroupRework aggregates rework per team of the PR author. Teams without reviewed PRs are omitted.
+func GroupRework(metrics []*ReviewMetrics, teams Teams) []ReworkGroup {
+	grouped := make(map[string]*ReworkGroup)
+	toLastPush := make(map[string][]time.Duration)
+
+	for _, m := range metrics {
+		if m.Rework == nil {
+			continue
+		}
+		for _, team := range teams.TeamsOf(m.PullRequest.Author) {
+			g, ok := grouped[team]
+			if !ok {
+				g = &ReworkGroup{Team: team}
+				grouped[team] = g
+			}
+			g.PRs++
+			if m.Rework.Commits > 0 {
+				g.Reworked++
+			}
+			g.Commits += m.Rework.Commits
+			g.Lines += m.Rework.Lines()
+			if m.ReviewToLastPush != nil {
+				toLastPush[team] = append(toLastPush[team], *m.ReviewToLastPush)
+			}
+		}
+	}
+
+	groups := make([]ReworkGroup, 0, len(grouped))
+	for _, team := range teams.Names() {
+		g, ok := grouped[team]
+		if !ok {
+			continue
+		}
+		g.ReviewToLastPush = Summarize(toLastPush[team])
+		groups = append(groups, *g)
+	}
+	return groups
+}
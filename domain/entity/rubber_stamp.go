@@ -0,0 +1,133 @@
+package entity
+
+import (
+	"sort"
+	"time"
+)
+
+type RubberStampReason string
+
+const (
+	// RubberStampFast is an approval given very quickly on a large PR
+	RubberStampFast RubberStampReason = "fast_approval"
+	// RubberStampSilent is an approval of a large PR without any comment by the approver
+	RubberStampSilent RubberStampReason = "no_comments"
+)
+
+// RubberStampRule flags approvals that were likely given without a real review
+type RubberStampRule struct {
+	// FastApproval flags approvals within this time of the review request on PRs with
+	// at least FastApprovalMinLines changed lines
+	FastApproval         time.Duration
+	FastApprovalMinLines int
+	// SilentApprovalMinLines flags approvals without comments on PRs with at least this many changed lines
+	SilentApprovalMinLines int
+}
+
+var DefaultRubberStampRule = RubberStampRule{
+	FastApproval:           5 * time.Minute,
+	FastApprovalMinLines:   300,
+	SilentApprovalMinLines: 500,
+}
+
+// RubberStamp is a flagged approval
+type RubberStamp struct {
+	Reviewer string
+	// ApprovedAfter is the time from the review request (or creation) until the approval
+	ApprovedAfter time.Duration
+	Reasons       []RubberStampReason
+}
+
+// Check returns the approvals of the PR flagged by the rule
+func (r RubberStampRule) Check(pr *PullRequest) []RubberStamp {
+	lines := pr.ChangedLines()
+	base := pr.ReviewBaseTime()
+
+	var stamps []RubberStamp
+	for _, a := range pr.Approvals {
+		stamp := RubberStamp{
+			Reviewer:      a.Reviewer,
+			ApprovedAfter: a.At.Sub(base),
+		}
+		if lines >= r.FastApprovalMinLines && stamp.ApprovedAfter <= r.FastApproval {
+			stamp.Reasons = append(stamp.Reasons, RubberStampFast)
+		}
+		if lines >= r.SilentApprovalMinLines && !pr.commentedBy(a.Reviewer) {
+			stamp.Reasons = append(stamp.Reasons, RubberStampSilent)
+		}
+		if len(stamp.Reasons) > 0 {
+			stamps = append(stamps, stamp)
+		}
+	}
+	return stamps
+}
+
+// commentedBy tells whether the login left any comment or review summary on the PR
+func (pr *PullRequest) commentedBy(login string) bool {
+	for _, c := range pr.Comments {
+		if c.Author == login {
+			return true
+		}
+	}
+	for _, r := range pr.Reviews {
+		if r.Reviewer == login && r.HasBody {
+			return true
+		}
+	}
+	return false
+}
+
+// RubberStampCount is how many of a reviewer's approvals were flagged
+type RubberStampCount struct {
+	Reviewer  string
+	Approvals int
+	Flagged   int
+	Fast      int
+	Silent    int
+}
+
+// CountRubberStamps counts flagged approvals per reviewer, most flagged first.
+// Reviewers without flagged approvals are omitted.
+func CountRubberStamps(metrics []*ReviewMetrics) []RubberStampCount {
+	counts := make(map[string]*RubberStampCount)
+	count := func(reviewer string) *RubberStampCount {
+		c, ok := counts[reviewer]
+		if !ok {
+			c = &RubberStampCount{Reviewer: reviewer}
+			counts[reviewer] = c
+		}
+		return c
+	}
+
+	for _, m := range metrics {
+		for _, a := range m.PullRequest.Approvals {
+			count(a.Reviewer).Approvals++
+		}
+		for _, s := range m.RubberStamps {
+			c := count(s.Reviewer)
+			c.Flagged++
+			for _, reason := range s.Reasons {
+				switch reason {
+				case RubberStampFast:
+					c.Fast++
+				case RubberStampSilent:
+					c.Silent++
+				}
+			}
+		}
+	}
+
+	var result []RubberStampCount
+	for _, c := range counts {
+		if c.Flagged > 0 {
+			result = append(result, *c)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Flagged != result[j].Flagged {
+			return result[i].Flagged > result[j].Flagged
+		}
+		return result[i].Reviewer < result[j].Reviewer
+	})
+	return result
+}
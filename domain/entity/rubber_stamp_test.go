@@ -0,0 +1,54 @@
+package entity
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestRubberStampRuleCheck(t *testing.T) {
+	rule := RubberStampRule{FastApproval: 10 * time.Minute, FastApprovalMinLines: 100, SilentApprovalMinLines: 200}
+	requestedAt := hoursAfter(1)
+
+	tests := []struct {
+		name     string
+		lines    int
+		after    time.Duration
+		comments []Comment
+		reviews  []Review
+		want     []RubberStampReason
+	}{
+		{"small PR", 50, time.Minute, nil, nil, nil},
+		{"fast at the limits", 100, 10 * time.Minute, nil, nil, []RubberStampReason{RubberStampFast}},
+		{"just too slow", 100, 10*time.Minute + time.Second, nil, nil, nil},
+		{"silent", 200, time.Hour, nil, nil, []RubberStampReason{RubberStampSilent}},
+		{"fast and silent", 200, time.Minute, nil, nil, []RubberStampReason{RubberStampFast, RubberStampSilent}},
+		{"commented", 200, time.Hour, []Comment{{Author: "alice", At: hoursAfter(1.5)}}, nil, nil},
+		{"review summary", 200, time.Hour, nil, []Review{{Reviewer: "alice", State: "APPROVED", HasBody: true}}, nil},
+		{"review without a summary", 200, time.Hour, nil, []Review{{Reviewer: "alice", State: "APPROVED"}}, []RubberStampReason{RubberStampSilent}},
+		{"comment by someone else", 200, time.Hour, []Comment{{Author: "bob", At: hoursAfter(1.5)}}, nil, []RubberStampReason{RubberStampSilent}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pr := &PullRequest{
+				CreatedAt:            hoursAfter(0),
+				FirstReviewRequestAt: &requestedAt,
+				Additions:            tt.lines / 2,
+				Deletions:            tt.lines - tt.lines/2,
+				Approvals:            []Approval{{Reviewer: "alice", At: requestedAt.Add(tt.after)}},
+				Comments:             tt.comments,
+				Reviews:              tt.reviews,
+			}
+			got := rule.Check(pr)
+			if tt.want == nil {
+				if got != nil {
+					t.Errorf("Check = %+v, want no flagged approvals", got)
+				}
+				return
+			}
+			if len(got) != 1 || got[0].Reviewer != "alice" || got[0].ApprovedAfter != tt.after || !slices.Equal(got[0].Reasons, tt.want) {
+				t.Errorf("Check = %+v, want alice after %v for %v", got, tt.after, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,337 @@
+package entity
+
+import (
+	"math"
+	"math/rand/v2"
+	"slices"
+	"sort"
+	"time"
+)
+
+// DurationSummary describes the distribution of a duration metric
+type DurationSummary struct {
+	Count  int
+	Mean   time.Duration
+	Median time.Duration
+	P75    time.Duration
+	P90    time.Duration
+	P95    time.Duration
+	Min    time.Duration
+	Max    time.Duration
+}
+
+func Summarize(durations []time.Duration) DurationSummary {
+	if len(durations) == 0 {
+		return DurationSummary{}
+	}
+
+	sorted := slices.Clone(durations)
+	slices.Sort(sorted)
+
+	var total time.Duration
+	for _, d := range sorted {
+		total += d
+	}
+
+	n := len(sorted)
+	return DurationSummary{
+		Count:  n,
+		Mean:   total / time.Duration(n),
+		Median: Percentile(sorted, 50),
+		P75:    Percentile(sorted, 75),
+		P90:    Percentile(sorted, 90),
+		P95:    Percentile(sorted, 95),
+		Min:    sorted[0],
+		Max:    sorted[n-1],
+	}
+}
+
+// Percentile returns the p-th percentile of sorted durations, interpolating linearly
+// between the closest ranks. It returns zero for no durations.
+func Percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+
+	rank := p / 100 * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	if lower >= len(sorted)-1 {
+		return sorted[len(sorted)-1]
+	}
+	fraction := rank - float64(lower)
+	return sorted[lower] + time.Duration(fraction*float64(sorted[lower+1]-sorted[lower]))
+}
+
+// minBootstrapSample is the fewest values of each sample a bootstrap is run for. Below it the
+// resampled medians take only a handful of values, and the interval would look falsely certain.
+const minBootstrapSample = 5
+
+// BootstrapMedianDifference estimates the confidence interval of median(b) - median(a) by
+// resampling both samples with replacement. It returns nil when either sample has fewer than
+// minBootstrapSample values.
+func BootstrapMedianDifference(a, b []time.Duration, resamples int, level float64, rng *rand.Rand) *ConfidenceInterval {
+	if len(a) < minBootstrapSample || len(b) < minBootstrapSample || resamples <= 0 {
+		return nil
+	}
+
+	differences := make([]time.Duration, resamples)
+	sampleA := make([]time.Duration, len(a))
+	sampleB := make([]time.Duration, len(b))
+	for i := range differences {
+		resample(sampleA, a, rng)
+		resample(sampleB, b, rng)
+		differences[i] = Percentile(sampleB, 50) - Percentile(sampleA, 50)
+	}
+	slices.Sort(differences)
+
+	tail := (1 - level) / 2 * 100
+	return &ConfidenceInterval{
+		Lower: Percentile(differences, tail),
+		Upper: Percentile(differences, 100-tail),
+		Level: level,
+	}
+}
+
+// RankTest is the result of a Mann-Whitney U test of whether values of one sample tend to be
+// larger than those of the other, without assuming a distribution of the durations
+type RankTest struct {
+	// U counts the pairs in which the value of the second sample is larger, ties counting half
+	U float64
+	Z float64
+	// PValue is two-sided, from the normal approximation with tie and continuity correction.
+	// It is rough for samples of fewer than about ten values.
+	PValue float64
+	// AdjustedPValue is PValue adjusted for the other tests run alongside, see AdjustHolm.
+	// It equals PValue for a test on its own.
+	AdjustedPValue float64
+	// EffectSize is the rank-biserial correlation in [-1, 1]. It is positive when values of the
+	// second sample tend to be larger, and zero when neither tends to be.
+	EffectSize float64
+}
+
+// MannWhitneyU tests whether the durations of b tend to differ from those of a.
+// It returns nil when either sample is empty.
+func MannWhitneyU(a, b []time.Duration) *RankTest {
+	if len(a) == 0 || len(b) == 0 {
+		return nil
+	}
+
+	pooled := make([]float64, 0, len(a)+len(b))
+	for _, d := range a {
+		pooled = append(pooled, float64(d))
+	}
+	for _, d := range b {
+		pooled = append(pooled, float64(d))
+	}
+
+	var rankSum float64
+	for _, r := range ranks(pooled)[len(a):] {
+		rankSum += r
+	}
+
+	n1, n2 := float64(len(a)), float64(len(b))
+	n := n1 + n2
+	u := rankSum - n2*(n2+1)/2
+	test := &RankTest{
+		U:              u,
+		PValue:         1,
+		AdjustedPValue: 1,
+		EffectSize:     2*u/(n1*n2) - 1,
+	}
+
+	// Ties shrink the variance of U
+	sorted := slices.Clone(pooled)
+	slices.Sort(sorted)
+	var ties float64
+	for i := 0; i < len(sorted); {
+		j := i
+		for j < len(sorted) && sorted[j] == sorted[i] {
+			j++
+		}
+		t := float64(j - i)
+		ties += t*t*t - t
+		i = j
+	}
+	variance := n1 * n2 / 12 * ((n + 1) - ties/(n*(n-1)))
+	if variance <= 0 {
+		// All values are equal
+		return test
+	}
+
+	diff := u - n1*n2/2
+	corrected := math.Max(math.Abs(diff)-0.5, 0)
+	test.Z = math.Copysign(corrected/math.Sqrt(variance), diff)
+	test.PValue = math.Erfc(corrected / math.Sqrt(variance) / math.Sqrt2)
+	test.AdjustedPValue = test.PValue
+	return test
+}
+
+// AdjustHolm sets the adjusted p-values of tests run together with the Holm-Bonferroni method,
+// so that the chance of any of them being significant by chance stays at the significance level.
+// Nil tests are skipped.
+func AdjustHolm(tests []*RankTest) {
+	var family []*RankTest
+	for _, t := range tests {
+		if t != nil {
+			family = append(family, t)
+		}
+	}
+	sort.SliceStable(family, func(i, j int) bool {
+		return family[i].PValue < family[j].PValue
+	})
+
+	// The i-th smallest p-value is multiplied by the number of tests not yet rejected,
+	// keeping the adjusted values in the same order
+	var running float64
+	for i, t := range family {
+		running = math.Max(running, math.Min(1, float64(len(family)-i)*t.PValue))
+		t.AdjustedPValue = running
+	}
+}
+
+// resample fills sample with values drawn from values with replacement, sorted
+func resample(sample, values []time.Duration, rng *rand.Rand) {
+	for i := range sample {
+		sample[i] = values[rng.IntN(len(values))]
+	}
+	slices.Sort(sample)
+}
+
+// LatencyGroup aggregates review latency of the PRs sharing a key, such as a size bucket
+type LatencyGroup struct {
+	Key string
+	// Outcome is empty when the group combines all outcomes
+	Outcome Outcome
+	Count   int
+	// Unreviewed is the number of PRs that never got a review
+	Unreviewed    int
+	TimeToReview  DurationSummary
+	TimeToApprove DurationSummary
+}
+
+// GroupLatency aggregates metrics per key in the given key order. Keys without PRs are omitted.
+// A PR belongs to every key returned by keysOf. With byOutcome, each key is further split
+// into merged, closed and open PRs.
+func GroupLatency(metrics []*ReviewMetrics, keys []string, keysOf func(*ReviewMetrics) []string, byOutcome bool) []LatencyGroup {
+	type groupKey struct {
+		key     string
+		outcome Outcome
+	}
+
+	grouped := make(map[groupKey][]*ReviewMetrics)
+	for _, m := range metrics {
+		for _, key := range keysOf(m) {
+			k := groupKey{key: key}
+			if byOutcome {
+				k.outcome = m.Outcome
+			}
+			grouped[k] = append(grouped[k], m)
+		}
+	}
+
+	outcomes := []Outcome{""}
+	if byOutcome {
+		outcomes = Outcomes
+	}
+
+	groups := make([]LatencyGroup, 0, len(keys))
+	for _, key := range keys {
+		for _, outcome := range outcomes {
+			members := grouped[groupKey{key: key, outcome: outcome}]
+			if len(members) == 0 {
+				continue
+			}
+
+			group := LatencyGroup{
+				Key:     key,
+				Outcome: outcome,
+				Count:   len(members),
+			}
+
+			var toReview, toApprove []time.Duration
+			for _, m := range members {
+				if m.TimeToReview != nil {
+					toReview = append(toReview, *m.TimeToReview)
+				} else {
+					group.Unreviewed++
+				}
+				if m.TimeToApprove != nil {
+					toApprove = append(toApprove, *m.TimeToApprove)
+				}
+			}
+			group.TimeToReview = Summarize(toReview)
+			group.TimeToApprove = Summarize(toApprove)
+
+			groups = append(groups, group)
+		}
+	}
+
+	return groups
+}
+
+// AreaLatency is review latency of a CODEOWNERS area, with how often its owners approved
+type AreaLatency struct {
+	LatencyGroup
+	// Approved is the number of approved PRs whose approvers could be checked against
+	// the area's owners, CodeOwnerApproved those approved by one of them
+	Approved          int
+	CodeOwnerApproved int
+}
+
+// RankCorrelation returns Spearman's rank correlation of the paired values, which is robust
+// to the skew of durations. It returns nil with fewer than three pairs or constant values.
+func RankCorrelation(x, y []float64) *float64 {
+	if len(x) != len(y) || len(x) < 3 {
+		return nil
+	}
+
+	rx, ry := ranks(x), ranks(y)
+
+	n := float64(len(x))
+	var meanX, meanY float64
+	for i := range rx {
+		meanX += rx[i]
+		meanY += ry[i]
+	}
+	meanX /= n
+	meanY /= n
+
+	var cov, varX, varY float64
+	for i := range rx {
+		dx, dy := rx[i]-meanX, ry[i]-meanY
+		cov += dx * dy
+		varX += dx * dx
+		varY += dy * dy
+	}
+	if varX == 0 || varY == 0 {
+		return nil
+	}
+
+	r := cov / math.Sqrt(varX*varY)
+	return &r
+}
+
+// ranks returns the 1-based ranks of the values, averaging the ranks of ties
+func ranks(values []float64) []float64 {
+	order := make([]int, len(values))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return values[order[i]] < values[order[j]]
+	})
+
+	result := make([]float64, len(values))
+	for i := 0; i < len(order); {
+		j := i
+		for j+1 < len(order) && values[order[j+1]] == values[order[i]] {
+			j++
+		}
+		rank := float64(i+j)/2 + 1
+		for k := i; k <= j; k++ {
+			result[order[k]] = rank
+		}
+		i = j + 1
+	}
+	return result
+}
@@ -0,0 +1,165 @@
+package entity
+
+import (
+	"math"
+	"math/rand/v2"
+	"testing"
+	"time"
+)
+
+func minutes(values ...float64) []time.Duration {
+	durations := make([]time.Duration, len(values))
+	for i, v := range values {
+		durations[i] = time.Duration(v * float64(time.Minute))
+	}
+	return durations
+}
+
+func TestPercentile(t *testing.T) {
+	tests := []struct {
+		name   string
+		sorted []time.Duration
+		p      float64
+		want   time.Duration
+	}{
+		{"empty", nil, 50, 0},
+		{"single value", minutes(5), 90, 5 * time.Minute},
+		{"minimum", minutes(1, 2, 3, 4), 0, 1 * time.Minute},
+		{"maximum", minutes(1, 2, 3, 4), 100, 4 * time.Minute},
+		{"median of even count interpolates", minutes(1, 2, 3, 4), 50, 150 * time.Second},
+		{"p75 interpolates between ranks", minutes(1, 2, 3, 4), 75, 195 * time.Second},
+		{"p90 of two values", minutes(10, 20), 90, 19 * time.Minute},
+		{"ties", minutes(2, 2, 2, 8), 50, 2 * time.Minute},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Percentile(tt.sorted, tt.p); got != tt.want {
+				t.Errorf("Percentile(%v, %v) = %v, want %v", tt.sorted, tt.p, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMannWhitneyU(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b []time.Duration
+		want *RankTest
+	}{
+		{
+			name: "empty sample",
+			a:    nil,
+			b:    minutes(1, 2),
+			want: nil,
+		},
+		{
+			// Ranks of b: 3.5, 7, 8, 9.5, 9.5, 11 = 48.5, U = 48.5 - 6*7/2.
+			// Two pairs of ties: var = 30/12 * (12 - 12/110), z = (|27.5 - 15| - 0.5) / sqrt(var)
+			name: "ties across samples",
+			a:    minutes(1, 2, 3, 4, 5),
+			b:    minutes(3, 6, 7, 8, 8, 9),
+			want: &RankTest{U: 27.5, Z: 2.2009, PValue: 0.02774, EffectSize: 0.8333},
+		},
+		{
+			// The continuity correction takes the whole difference of 0.5 away
+			name: "single values",
+			a:    minutes(1),
+			b:    minutes(2),
+			want: &RankTest{U: 1, Z: 0, PValue: 1, EffectSize: 1},
+		},
+		{
+			name: "second sample smaller",
+			a:    minutes(4, 5, 6),
+			b:    minutes(1, 2, 3),
+			// var = 9/12 * 7, z = -(4.5 - 0.5) / sqrt(5.25)
+			want: &RankTest{U: 0, Z: -1.7457, PValue: 0.08086, EffectSize: -1},
+		},
+		{
+			name: "all values equal",
+			a:    minutes(5, 5),
+			b:    minutes(5),
+			want: &RankTest{U: 1, Z: 0, PValue: 1, EffectSize: 0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := MannWhitneyU(tt.a, tt.b)
+			if tt.want == nil {
+				if got != nil {
+					t.Fatalf("MannWhitneyU = %+v, want nil", got)
+				}
+				return
+			}
+			if got == nil {
+				t.Fatalf("MannWhitneyU = nil, want %+v", tt.want)
+			}
+			for _, c := range []struct {
+				name      string
+				got, want float64
+			}{
+				{"U", got.U, tt.want.U},
+				{"Z", got.Z, tt.want.Z},
+				{"PValue", got.PValue, tt.want.PValue},
+				{"AdjustedPValue", got.AdjustedPValue, tt.want.PValue},
+				{"EffectSize", got.EffectSize, tt.want.EffectSize},
+			} {
+				if math.Abs(c.got-c.want) > 1e-4 {
+					t.Errorf("%s = %.5f, want %.5f", c.name, c.got, c.want)
+				}
+			}
+		})
+	}
+}
+
+func TestAdjustHolm(t *testing.T) {
+	tests := []*RankTest{{PValue: 0.01}, nil, {PValue: 0.04}, {PValue: 0.03}, {PValue: 0.5}}
+	AdjustHolm(tests)
+
+	// Sorted: 0.01*4, 0.03*3, 0.04*2, 0.5*1, each at least the previous one
+	want := []float64{0.04, 0, 0.09, 0.09, 0.5}
+	for i, tt := range tests {
+		if tt == nil {
+			continue
+		}
+		if math.Abs(tt.AdjustedPValue-want[i]) > 1e-9 {
+			t.Errorf("AdjustedPValue of p = %v is %v, want %v", tt.PValue, tt.AdjustedPValue, want[i])
+		}
+	}
+}
+
+func TestBootstrapMedianDifference(t *testing.T) {
+	tests := []struct {
+		name      string
+		a, b      []time.Duration
+		resamples int
+		want      *ConfidenceInterval
+	}{
+		{"first sample below the minimum", minutes(1, 2, 3, 4), minutes(1, 2, 3, 4, 5), 100, nil},
+		{"second sample below the minimum", minutes(1, 2, 3, 4, 5), minutes(1, 2, 3, 4), 100, nil},
+		{"no resamples", minutes(1, 2, 3, 4, 5), minutes(1, 2, 3, 4, 5), 0, nil},
+		// Every resample of a constant sample has the same median
+		{"constant samples at the minimum", minutes(10, 10, 10, 10, 10), minutes(25, 25, 25, 25, 25), 100,
+			&ConfidenceInterval{Lower: 15 * time.Minute, Upper: 15 * time.Minute, Level: 0.95}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := BootstrapMedianDifference(tt.a, tt.b, tt.resamples, 0.95, rand.New(rand.NewPCG(1, 2)))
+			if !equalPtr(got, tt.want) {
+				t.Errorf("BootstrapMedianDifference = %+v, want %+v", deref(got), deref(tt.want))
+			}
+		})
+	}
+
+	t.Run("interval around the observed difference", func(t *testing.T) {
+		a := minutes(10, 12, 14, 16, 18)
+		b := minutes(30, 32, 34, 36, 38)
+		got := BootstrapMedianDifference(a, b, 1000, 0.95, rand.New(rand.NewPCG(1, 2)))
+		if got == nil {
+			t.Fatal("BootstrapMedianDifference = nil, want an interval")
+		}
+		// Resampled medians stay within the samples, so the difference is between 12m and 28m
+		if got.Lower > 20*time.Minute || got.Upper < 20*time.Minute || got.Lower < 12*time.Minute || got.Upper > 28*time.Minute {
+			t.Errorf("BootstrapMedianDifference = [%v, %v], want around 20m within [12m, 28m]", got.Lower, got.Upper)
+		}
+	})
+}
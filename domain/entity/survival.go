@@ -0,0 +1,148 @@
+package entity
+
+import (
+	"sort"
+	"time"
+)
+
+// Observation is how long a PR waited, and whether the wait ended with the event (such as a
+// review) or was censored because the PR was closed or is still open without it
+type Observation struct {
+	Duration time.Duration
+	Event    bool
+}
+
+// SurvivalStep is the estimated share of PRs still waiting after an event time
+type SurvivalStep struct {
+	At       time.Duration
+	Survival float64
+}
+
+// SurvivalCurve is a Kaplan-Meier estimate of the share of PRs still waiting over time.
+// Censored PRs count as waiting until they drop out, instead of being left out.
+type SurvivalCurve struct {
+	Observations int
+	Events       int
+	Steps        []SurvivalStep
+	// Longest is the longest observed wait; the curve is unknown beyond it
+	Longest time.Duration
+}
+
+// KaplanMeier estimates the survival curve of the observations
+func KaplanMeier(observations []Observation) SurvivalCurve {
+	sorted := make([]Observation, len(observations))
+	copy(sorted, observations)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Duration < sorted[j].Duration
+	})
+
+	curve := SurvivalCurve{Observations: len(sorted)}
+	survival := 1.0
+	atRisk := len(sorted)
+	for i := 0; i < len(sorted); {
+		// Events at a time are counted before the PRs censored at the same time drop out
+		at := sorted[i].Duration
+		var events, dropped int
+		for ; i < len(sorted) && sorted[i].Duration == at; i++ {
+			if sorted[i].Event {
+				events++
+			}
+			dropped++
+		}
+
+		if events > 0 {
+			survival *= 1 - float64(events)/float64(atRisk)
+			curve.Steps = append(curve.Steps, SurvivalStep{At: at, Survival: survival})
+			curve.Events += events
+		}
+		atRisk -= dropped
+		curve.Longest = at
+	}
+	return curve
+}
+
+// StillWaiting returns the estimated share of PRs still waiting after d, or nil when d is
+// beyond the longest observed wait and the curve has not reached zero
+func (c SurvivalCurve) StillWaiting(d time.Duration) *float64 {
+	survival := 1.0
+	for _, s := range c.Steps {
+		if s.At > d {
+			break
+		}
+		survival = s.Survival
+	}
+	if d > c.Longest && survival > 0 {
+		return nil
+	}
+	return &survival
+}
+
+// Median returns the first time at which at most half of the PRs are still waiting,
+// or nil when the curve never gets there
+func (c SurvivalCurve) Median() *time.Duration {
+	for _, s := range c.Steps {
+		if s.Survival <= 0.5 {
+			at := s.At
+			return &at
+		}
+	}
+	return nil
+}
+
+// SurvivalHorizon is a point in time the share of PRs still waiting is reported at
+type SurvivalHorizon struct {
+	Label string
+	After time.Duration
+}
+
+// SurvivalHorizons are the points reported for every survival curve
+var SurvivalHorizons = []SurvivalHorizon{
+	{Label: "1h", After: time.Hour},
+	{Label: "4h", After: 4 * time.Hour},
+	{Label: "1d", After: 24 * time.Hour},
+	{Label: "3d", After: 3 * 24 * time.Hour},
+	{Label: "1w", After: 7 * 24 * time.Hour},
+}
+
+// MetricSurvival is the survival curve of the wait for a review event
+type MetricSurvival struct {
+	Metric DurationMetric
+	Curve  SurvivalCurve
+}
+
+// SurvivalMetrics are the waits estimated with censoring: each is measured until the event,
+// or censored when the PR was closed, or is still open at the end of the measurement, without it
+var SurvivalMetrics = []struct {
+	Metric  DurationMetric
+	EventAt func(*PullRequest) *time.Time
+}{
+	{Metric: durationMetric("time_to_review"), EventAt: func(pr *PullRequest) *time.Time { return pr.FirstReviewAt }},
+	{Metric: durationMetric("time_to_approve"), EventAt: func(pr *PullRequest) *time.Time { return pr.FirstApproveAt }},
+}
+
+// EstimateSurvival estimates the survival curves of the SurvivalMetrics. Open PRs are censored
+// at end; they are left out when end is zero.
+func EstimateSurvival(metrics []*ReviewMetrics, end time.Time) []MetricSurvival {
+	estimates := make([]MetricSurvival, 0, len(SurvivalMetrics))
+	for _, s := range SurvivalMetrics {
+		var observations []Observation
+		for _, m := range metrics {
+			pr := m.PullRequest
+			if at := s.EventAt(pr); at != nil {
+				observations = append(observations, Observation{Duration: at.Sub(pr.ReviewBaseTime()), Event: true})
+				continue
+			}
+
+			censoredAt := end
+			if pr.ClosedAt != nil {
+				censoredAt = *pr.ClosedAt
+			}
+			if censoredAt.IsZero() {
+				continue
+			}
+			observations = append(observations, Observation{Duration: max(censoredAt.Sub(pr.ReviewBaseTime()), 0)})
+		}
+		estimates = append(estimates, MetricSurvival{Metric: s.Metric, Curve: KaplanMeier(observations)})
+	}
+	return estimates
+}
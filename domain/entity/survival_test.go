@@ -0,0 +1,141 @@
+package entity
+
+import (
+	"math"
+	"slices"
+	"testing"
+	"time"
+)
+
+func observations(hours ...float64) []Observation {
+	// Negative hours are censored observations
+	result := make([]Observation, len(hours))
+	for i, h := range hours {
+		result[i] = Observation{Duration: time.Duration(math.Abs(h) * float64(time.Hour)), Event: h > 0}
+	}
+	return result
+}
+
+func TestKaplanMeier(t *testing.T) {
+	tests := []struct {
+		name         string
+		observations []Observation
+		wantEvents   int
+		wantSteps    []SurvivalStep
+		wantLongest  time.Duration
+		wantMedian   *time.Duration
+	}{
+		{
+			name:         "empty",
+			observations: nil,
+		},
+		{
+			name:         "single censored",
+			observations: observations(-1),
+			wantLongest:  time.Hour,
+		},
+		{
+			name:         "single event",
+			observations: observations(2),
+			wantEvents:   1,
+			wantSteps:    []SurvivalStep{{At: 2 * time.Hour, Survival: 0}},
+			wantLongest:  2 * time.Hour,
+			wantMedian:   ptr(2 * time.Hour),
+		},
+		{
+			// 1h: 1 of 6 -> 5/6; 2h censored; 3h: 1 of 4 -> 5/6 * 3/4 = 0.625, and the PR censored
+			// at 3h is still at risk; 4h: 1 of 2 -> 0.3125; 5h censored
+			name:         "censoring at event times",
+			observations: observations(3, -2, 1, -3, 4, -5),
+			wantEvents:   3,
+			wantSteps: []SurvivalStep{
+				{At: time.Hour, Survival: 5.0 / 6},
+				{At: 3 * time.Hour, Survival: 0.625},
+				{At: 4 * time.Hour, Survival: 0.3125},
+			},
+			wantLongest: 5 * time.Hour,
+			wantMedian:  ptr(4 * time.Hour),
+		},
+		{
+			// 2h: 2 of 4 -> 0.5; 3h: 1 of 2 -> 0.25
+			name:         "tied events",
+			observations: observations(2, 2, 3, -3),
+			wantEvents:   3,
+			wantSteps: []SurvivalStep{
+				{At: 2 * time.Hour, Survival: 0.5},
+				{At: 3 * time.Hour, Survival: 0.25},
+			},
+			wantLongest: 3 * time.Hour,
+			wantMedian:  ptr(2 * time.Hour),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			curve := KaplanMeier(tt.observations)
+			if curve.Observations != len(tt.observations) {
+				t.Errorf("Observations = %d, want %d", curve.Observations, len(tt.observations))
+			}
+			if curve.Events != tt.wantEvents {
+				t.Errorf("Events = %d, want %d", curve.Events, tt.wantEvents)
+			}
+			if !slices.EqualFunc(curve.Steps, tt.wantSteps, func(a, b SurvivalStep) bool {
+				return a.At == b.At && math.Abs(a.Survival-b.Survival) < 1e-9
+			}) {
+				t.Errorf("Steps = %v, want %v", curve.Steps, tt.wantSteps)
+			}
+			if curve.Longest != tt.wantLongest {
+				t.Errorf("Longest = %v, want %v", curve.Longest, tt.wantLongest)
+			}
+			if got := curve.Median(); !equalPtr(got, tt.wantMedian) {
+				t.Errorf("Median() = %v, want %v", deref(got), deref(tt.wantMedian))
+			}
+		})
+	}
+}
+
+func TestSurvivalCurveStillWaiting(t *testing.T) {
+	tests := []struct {
+		name         string
+		observations []Observation
+		after        time.Duration
+		want         *float64
+	}{
+		{"empty at zero", nil, 0, ptr(1.0)},
+		{"empty beyond", nil, time.Hour, nil},
+		{"before the first event", observations(1, -2, 3), 30 * time.Minute, ptr(1.0)},
+		{"at an event", observations(1, -2, 3), time.Hour, ptr(2.0 / 3)},
+		{"between events", observations(1, -2, 3), 2 * time.Hour, ptr(2.0 / 3)},
+		// At 3h one PR is left at risk, and its review ends the waiting
+		{"at the last event", observations(1, -2, 3), 3 * time.Hour, ptr(0.0)},
+		{"beyond the curve reaching zero", observations(1, -2, 3), 10 * time.Hour, ptr(0.0)},
+		{"at the longest censored wait", observations(1, -4), 4 * time.Hour, ptr(0.5)},
+		{"beyond the longest censored wait", observations(1, -4), 5 * time.Hour, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := KaplanMeier(tt.observations).StillWaiting(tt.after)
+			if (got == nil) != (tt.want == nil) || (got != nil && math.Abs(*got-*tt.want) > 1e-9) {
+				t.Errorf("StillWaiting(%v) = %v, want %v", tt.after, deref(got), deref(tt.want))
+			}
+		})
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func equalPtr[T comparable](a, b *T) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// deref formats a pointer for test messages, showing nil instead of an address
+func deref[T any](p *T) any {
+	if p == nil {
+		return nil
+	}
+	return *p
+}
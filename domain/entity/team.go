@@ -0,0 +1,37 @@
+package entity
+
+import (
+	"slices"
+	"sort"
+)
+
+// NoTeam is the team of authors who belong to none of the configured teams
+const NoTeam = "(no team)"
+
+// Teams maps team names to the logins of their members
+type Teams map[string][]string
+
+// Names returns the team names in order, followed by NoTeam
+func (t Teams) Names() []string {
+	names := make([]string, 0, len(t)+1)
+	for name := range t {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return append(names, NoTeam)
+}
+
+// TeamsOf returns the teams the login belongs to, or NoTeam when it belongs to none
+func (t Teams) TeamsOf(login string) []string {
+	var teams []string
+	for name, members := range t {
+		if slices.Contains(members, login) {
+			teams = append(teams, name)
+		}
+	}
+	if len(teams) == 0 {
+		return []string{NoTeam}
+	}
+	sort.Strings(teams)
+	return teams
+}
@@ -0,0 +1,110 @@
+package entity
+
+import "time"
+
+// TrendInterval is the length of the buckets PRs are grouped into over time
+type TrendInterval string
+
+const (
+	TrendDay   TrendInterval = "day"
+	TrendWeek  TrendInterval = "week"
+	TrendMonth TrendInterval = "month"
+)
+
+// Start returns the start of the bucket containing t in UTC. Weeks start on Monday.
+func (i TrendInterval) Start(t time.Time) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	switch i {
+	case TrendWeek:
+		return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+	case TrendMonth:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return day
+	}
+}
+
+// Next returns the start of the bucket following the one starting at start
+func (i TrendInterval) Next(start time.Time) time.Time {
+	switch i {
+	case TrendWeek:
+		return start.AddDate(0, 0, 7)
+	case TrendMonth:
+		return start.AddDate(0, 1, 0)
+	default:
+		return start.AddDate(0, 0, 1)
+	}
+}
+
+// Label names the bucket starting at start, e.g. 2024-01 for a month
+func (i TrendInterval) Label(start time.Time) string {
+	if i == TrendMonth {
+		return start.Format("2006-01")
+	}
+	return start.Format("2006-01-02")
+}
+
+// TrendDate is the date of a PR that decides its bucket
+type TrendDate string
+
+const (
+	TrendByCreated TrendDate = "created"
+	TrendByMerged  TrendDate = "merged"
+)
+
+// Of returns the date of the PR, or nil when it has none, e.g. when it was not merged
+func (d TrendDate) Of(pr *PullRequest) *time.Time {
+	if d == TrendByMerged {
+		return pr.MergedAt
+	}
+	return &pr.CreatedAt
+}
+
+// TrendBucket summarizes the PRs dated within [Start, End)
+type TrendBucket struct {
+	Start   time.Time
+	End     time.Time
+	Summary Summary
+}
+
+// TrendReport is the result of a trend measurement: consecutive buckets from the first
+// to the last dated PR, including buckets without PRs so the series can be charted directly
+type TrendReport struct {
+	Owner    string
+	Repo     string
+	Interval TrendInterval
+	By       TrendDate
+	Buckets  []TrendBucket
+	// Undated is the number of PRs left out for lack of the date, such as unmerged PRs
+	Undated    int
+	Exclusions []Exclusion
+}
+
+// Metrics returns the duration metrics measured in any bucket, in report column order
+func (r *TrendReport) Metrics() []DurationMetric {
+	measured := make(map[string]bool)
+	for _, b := range r.Buckets {
+		for _, m := range b.Summary.Metrics {
+			measured[m.Metric.Key] = true
+		}
+	}
+
+	var metrics []DurationMetric
+	for _, m := range DurationMetrics {
+		if measured[m.Key] {
+			metrics = append(metrics, m)
+		}
+	}
+	return metrics
+}
+
+// Metric returns the summary of the metric with the key, empty when it was not measured
+func (s Summary) Metric(key string) DurationSummary {
+	for _, m := range s.Metrics {
+		if m.Metric.Key == key {
+			return m.DurationSummary
+		}
+	}
+	return DurationSummary{}
+}
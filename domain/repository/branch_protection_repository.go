@@ -0,0 +1,10 @@
+package repository
+
+import (
+	"context"
+)
+
+type BranchProtectionRepository interface {
+	// GetRequiredApprovals returns the number of approvals branch protection requires for the branch
+	GetRequiredApprovals(ctx context.Context, owner, repo, branch string) (int, error)
+}
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/dragoneena12/measure-review-time/domain/entity"
+)
+
+type CodeOwnersRepository interface {
+	// GetCodeOwners fetches the CODEOWNERS file from the default branch
+	GetCodeOwners(ctx context.Context, owner, repo string) (*entity.CodeOwners, error)
+	// ListTeamMembers returns the logins of the members of an organization team
+	ListTeamMembers(ctx context.Context, org, slug string) ([]string, error)
+}
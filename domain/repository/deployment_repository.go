@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/dragoneena12/measure-review-time/domain/entity"
+)
+
+type DeploymentRepository interface {
+	// ListDeployments returns the successful deployments to the environment since the given time,
+	// oldest first
+	ListDeployments(ctx context.Context, owner, repo, environment string, since time.Time) ([]entity.Deployment, error)
+	// ListReleases returns the published, non-draft releases since the given time, oldest first
+	ListReleases(ctx context.Context, owner, repo string, since time.Time) ([]entity.Deployment, error)
+	// ContainsCommit tells whether the commit is an ancestor of (or equal to) ref
+	ContainsCommit(ctx context.Context, owner, repo, ref, sha string) (bool, error)
+}
@@ -5,5 +5,8 @@ import (
 )
 
 type Printer interface {
-	Print(owner, repo string, metrics []*entity.ReviewMetrics) error
-}
\ No newline at end of file
+	Print(report *entity.Report) error
+	PrintOpenPRs(report *entity.AgingReport) error
+	PrintTrend(report *entity.TrendReport) error
+	PrintComparison(report *entity.ComparisonReport) error
+}
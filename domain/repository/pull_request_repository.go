@@ -19,4 +19,20 @@ type ListOptions struct {
 	Since     *time.Time
 	Until     *time.Time
 	PerPage   int
-}
\ No newline at end of file
+	Filter    *entity.AccountFilter
+
+	// Labels, Base and Authors are pushed into the search query
+	Labels  []string
+	Base    string
+	Authors []string
+	// Paths are matched client-side against the changed files of each PR
+	Paths []*entity.PathGlob
+	// ExcludeDrafts skips draft PRs
+	ExcludeDrafts bool
+	// FetchFiles fetches the changed files of every PR even without Paths
+	FetchFiles bool
+	// FetchCommits fetches the commits of every PR
+	FetchCommits bool
+	// FetchReworkStats also fetches the changed lines of the commits made after the first review
+	FetchReworkStats bool
+}
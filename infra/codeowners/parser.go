@@ -0,0 +1,68 @@
+package codeowners
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/dragoneena12/measure-review-time/domain/entity"
+)
+
+// Load parses a local CODEOWNERS file
+func Load(path string) (*entity.CodeOwners, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open CODEOWNERS file: %w", err)
+	}
+	defer f.Close()
+
+	return Parse(f)
+}
+
+// Parse reads CODEOWNERS rules. Patterns follow the gitignore-style rules GitHub uses:
+// a pattern without a slash matches at any depth, and a leading slash anchors it to the root.
+func Parse(r io.Reader) (*entity.CodeOwners, error) {
+	co := &entity.CodeOwners{}
+
+	scanner := bufio.NewScanner(r)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if i := strings.Index(line, " #"); i >= 0 {
+			line = line[:i]
+		}
+
+		fields := strings.Fields(line)
+		glob, err := entity.CompilePathGlob(toGlob(fields[0]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid CODEOWNERS pattern on line %d: %w", lineNo, err)
+		}
+
+		co.Rules = append(co.Rules, entity.CodeOwnersRule{
+			Pattern: glob,
+			Owners:  fields[1:],
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read CODEOWNERS: %w", err)
+	}
+
+	return co, nil
+}
+
+func toGlob(pattern string) string {
+	if strings.HasPrefix(pattern, "/") {
+		return strings.TrimPrefix(pattern, "/")
+	}
+	// Without a slash other than a trailing one, the pattern matches at any depth
+	if !strings.Contains(strings.TrimSuffix(pattern, "/"), "/") {
+		return "**/" + pattern
+	}
+	return pattern
+}
@@ -0,0 +1,94 @@
+package codeowners
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name       string
+		codeowners string
+		file       string
+		want       []string
+	}{
+		{
+			name:       "no matching rule",
+			codeowners: "/docs/ @docs",
+			file:       "main.go",
+			want:       nil,
+		},
+		{
+			name:       "extension at any depth",
+			codeowners: "*.js @frontend",
+			file:       "web/src/app.js",
+			want:       []string{"@frontend"},
+		},
+		{
+			name:       "last matching rule wins",
+			codeowners: "* @everyone\n*.go @gophers",
+			file:       "cmd/main.go",
+			want:       []string{"@gophers"},
+		},
+		{
+			name:       "directory pattern matches nested files",
+			codeowners: "/build/logs/ @ops",
+			file:       "build/logs/2024/01/app.log",
+			want:       []string{"@ops"},
+		},
+		{
+			name:       "directory name without slash matches at any depth",
+			codeowners: "apps/ @octocat",
+			file:       "src/apps/web/index.ts",
+			want:       []string{"@octocat"},
+		},
+		{
+			name:       "path without trailing slash matches the directory contents",
+			codeowners: "/docs @docs",
+			file:       "docs/build/setup.md",
+			want:       []string{"@docs"},
+		},
+		{
+			name:       "star matches files directly in the directory",
+			codeowners: "docs/* docs@example.com",
+			file:       "docs/getting-started.md",
+			want:       []string{"docs@example.com"},
+		},
+		{
+			name:       "star does not match nested files",
+			codeowners: "docs/* docs@example.com",
+			file:       "docs/build-app/troubleshooting.md",
+			want:       nil,
+		},
+		{
+			name:       "anchored pattern does not match deeper",
+			codeowners: "/scripts/ @ops",
+			file:       "tools/scripts/run.sh",
+			want:       nil,
+		},
+		{
+			name:       "comments and multiple owners",
+			codeowners: "# owners\n\n/api/ @org/backend @alice # API team\n",
+			file:       "api/handler.go",
+			want:       []string{"@org/backend", "@alice"},
+		},
+		{
+			name:       "empty owners unset ownership",
+			codeowners: "* @everyone\n/vendor/",
+			file:       "vendor/lib.go",
+			want:       []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			co, err := Parse(strings.NewReader(tt.codeowners))
+			if err != nil {
+				t.Fatalf("Parse returned error: %v", err)
+			}
+			if got := co.OwnersOf(tt.file); !slices.Equal(got, tt.want) {
+				t.Errorf("OwnersOf(%q) = %q, want %q", tt.file, got, tt.want)
+			}
+		})
+	}
+}
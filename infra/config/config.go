@@ -0,0 +1,145 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"time"
+
+	"github.com/dragoneena12/measure-review-time/domain/entity"
+)
+
+// Config is the optional JSON configuration file given with -config
+type Config struct {
+	Filters FilterConfig          `json:"filters"`
+	Sizes   *SizeThresholdsConfig `json:"size_thresholds"`
+	// RequiredApprovals maps "owner/repo" to the number of approvals needed to merge
+	RequiredApprovals map[string]int `json:"required_approvals"`
+	// Teams maps team names to the logins of their members
+	Teams map[string][]string `json:"teams"`
+	// RubberStamp tunes the rule flagging suspiciously fast or silent approvals
+	RubberStamp *RubberStampConfig `json:"rubber_stamp"`
+}
+
+type FilterConfig struct {
+	AllowLogins           []string `json:"allow_logins"`
+	DenyLogins            []string `json:"deny_logins"`
+	DenyPatterns          []string `json:"deny_patterns"`
+	ExcludeAuthors        []string `json:"exclude_authors"`
+	ExcludeAuthorPatterns []string `json:"exclude_author_patterns"`
+	ExcludeBotAuthors     bool     `json:"exclude_bot_authors"`
+	AutomatedReviewers    []string `json:"automated_reviewers"`
+	AutomatedPatterns     []string `json:"automated_reviewer_patterns"`
+}
+
+// SizeThresholdsConfig are the inclusive upper bounds of changed lines for each size bucket
+type SizeThresholdsConfig struct {
+	XS int `json:"xs"`
+	S  int `json:"s"`
+	M  int `json:"m"`
+	L  int `json:"l"`
+}
+
+// RubberStampConfig overrides the default rule. Omitted values keep their defaults.
+type RubberStampConfig struct {
+	FastApprovalMinutes    *int `json:"fast_approval_minutes"`
+	FastApprovalMinLines   *int `json:"fast_approval_min_lines"`
+	SilentApprovalMinLines *int `json:"silent_approval_min_lines"`
+}
+
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config file: %w", err)
+	}
+
+	return &cfg, nil
+}
+
+// AccountFilter builds the domain filter, compiling the configured patterns
+func (c *Config) AccountFilter() (*entity.AccountFilter, error) {
+	denyPatterns, err := compilePatterns(c.Filters.DenyPatterns)
+	if err != nil {
+		return nil, err
+	}
+	authorPatterns, err := compilePatterns(c.Filters.ExcludeAuthorPatterns)
+	if err != nil {
+		return nil, err
+	}
+	automatedPatterns, err := compilePatterns(c.Filters.AutomatedPatterns)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.AccountFilter{
+		AllowLogins:           c.Filters.AllowLogins,
+		DenyLogins:            c.Filters.DenyLogins,
+		DenyPatterns:          denyPatterns,
+		ExcludeAuthors:        c.Filters.ExcludeAuthors,
+		ExcludeAuthorPatterns: authorPatterns,
+		ExcludeBotAuthors:     c.Filters.ExcludeBotAuthors,
+		AutomatedLogins:       c.Filters.AutomatedReviewers,
+		AutomatedPatterns:     automatedPatterns,
+	}, nil
+}
+
+func compilePatterns(patterns []string) ([]*regexp.Regexp, error) {
+	compiled := make([]*regexp.Regexp, 0, len(patterns))
+	for _, p := range patterns {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %w", p, err)
+		}
+		compiled = append(compiled, re)
+	}
+	return compiled, nil
+}
+
+// SizeThresholds returns the configured size buckets, or the defaults when not configured
+func (c *Config) SizeThresholds() (entity.SizeThresholds, error) {
+	if c.Sizes == nil {
+		return entity.DefaultSizeThresholds, nil
+	}
+
+	t := entity.SizeThresholds{
+		XS: c.Sizes.XS,
+		S:  c.Sizes.S,
+		M:  c.Sizes.M,
+		L:  c.Sizes.L,
+	}
+	if t.XS >= t.S || t.S >= t.M || t.M >= t.L {
+		return entity.SizeThresholds{}, fmt.Errorf("size thresholds must be increasing: xs < s < m < l")
+	}
+	return t, nil
+}
+
+// RubberStampRule returns the default rule with the configured values applied
+func (c *Config) RubberStampRule() (entity.RubberStampRule, error) {
+	rule := entity.DefaultRubberStampRule
+	r := c.RubberStamp
+	if r == nil {
+		return rule, nil
+	}
+
+	for _, v := range []*int{r.FastApprovalMinutes, r.FastApprovalMinLines, r.SilentApprovalMinLines} {
+		if v != nil && *v < 0 {
+			return entity.RubberStampRule{}, fmt.Errorf("rubber_stamp values must not be negative")
+		}
+	}
+	if r.FastApprovalMinutes != nil {
+		rule.FastApproval = time.Duration(*r.FastApprovalMinutes) * time.Minute
+	}
+	if r.FastApprovalMinLines != nil {
+		rule.FastApprovalMinLines = *r.FastApprovalMinLines
+	}
+	if r.SilentApprovalMinLines != nil {
+		rule.SilentApprovalMinLines = *r.SilentApprovalMinLines
+	}
+	return rule, nil
+}
@@ -0,0 +1,32 @@
+package github
+
+import (
+	"context"
+	"log/slog"
+)
+
+func (c *Client) GetRequiredApprovals(ctx context.Context, owner, repo, branch string) (int, error) {
+	c.logger.Debug("Fetching branch protection",
+		slog.String("owner", owner),
+		slog.String("repo", repo),
+		slog.String("branch", branch),
+	)
+
+	protection, _, err := c.client.Repositories.GetBranchProtection(ctx, owner, repo, branch)
+	if err != nil {
+		c.logger.Warn("Failed to get branch protection",
+			slog.String("owner", owner),
+			slog.String("repo", repo),
+			slog.String("branch", branch),
+			slog.String("error", err.Error()),
+		)
+		return 0, err
+	}
+
+	reviews := protection.GetRequiredPullRequestReviews()
+	if reviews == nil {
+		// The branch is protected but does not require reviews
+		return 0, nil
+	}
+	return reviews.RequiredApprovingReviewCount, nil
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"slices"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/dragoneena12/measure-review-time/domain/entity"
@@ -35,30 +37,8 @@ func NewClient(token string, logger *slog.Logger) *Client {
 }
 
 func (c *Client) List(ctx context.Context, owner, repo string, opts repository.ListOptions) ([]*entity.PullRequest, error) {
-	// Build search query
-	query := fmt.Sprintf("repo:%s/%s is:pr", owner, repo)
-	
-	// Add state filter
-	if opts.State != "" {
-		if opts.State == "closed" {
-			query += " is:closed"
-		} else if opts.State == "open" {
-			query += " is:open"
-		}
-	}
-	
-	// Add date filters
-	if opts.Since != nil && opts.Until != nil {
-		// When both are specified, use range syntax
-		query += fmt.Sprintf(" created:%s..%s", opts.Since.Format("2006-01-02"), opts.Until.Format("2006-01-02"))
-	} else if opts.Since != nil {
-		// Only since is specified
-		query += fmt.Sprintf(" created:>=%s", opts.Since.Format("2006-01-02"))
-	} else if opts.Until != nil {
-		// Only until is specified
-		query += fmt.Sprintf(" created:<=%s", opts.Until.Format("2006-01-02"))
-	}
-	
+	query := searchQuery(owner, repo, opts)
+
 	// Initialize result collection
 	var allIssues []*github.Issue
 	page := 1
@@ -66,7 +46,7 @@ func (c *Client) List(ctx context.Context, owner, repo string, opts repository.L
 	if perPage == 0 {
 		perPage = 100 // Default per page
 	}
-	
+
 	// Fetch all pages
 	for {
 		searchOpts := &github.SearchOptions{
@@ -114,7 +94,7 @@ func (c *Client) List(ctx context.Context, owner, repo string, opts repository.L
 		)
 
 		allIssues = append(allIssues, searchResult.Issues...)
-		
+
 		// Check if there are more pages
 		if resp.NextPage == 0 {
 			break
@@ -130,14 +110,29 @@ func (c *Client) List(ctx context.Context, owner, repo string, opts repository.L
 
 	result := make([]*entity.PullRequest, 0, len(allIssues))
 	totalIssues := len(allIssues)
-	
+
 	for i, issue := range allIssues {
 		// Display progress
 		c.logger.Info("Processing pull request",
 			slog.String("progress", fmt.Sprintf("%d/%d", i+1, totalIssues)),
 			slog.Int("number", *issue.Number),
 		)
-		
+
+		if len(opts.Authors) > 1 && !slices.Contains(opts.Authors, issue.GetUser().GetLogin()) {
+			continue
+		}
+
+		// Skip PRs by excluded authors before fetching their details
+		if rule := opts.Filter.AuthorExclusion(issue.GetUser().GetLogin(), issue.GetUser().GetType() == "Bot"); rule != "" {
+			c.logger.Debug("Skipping pull request by excluded author",
+				slog.Int("number", *issue.Number),
+				slog.String("author", issue.GetUser().GetLogin()),
+				slog.String("rule", rule),
+			)
+			opts.Filter.Record(rule)
+			continue
+		}
+
 		// Get full PR details
 		pr, _, err := c.client.PullRequests.Get(ctx, owner, repo, *issue.Number)
 		if err != nil {
@@ -149,28 +144,56 @@ func (c *Client) List(ctx context.Context, owner, repo string, opts repository.L
 			)
 			return nil, err
 		}
-		
-		pullRequest := c.convertToDomainEntity(pr)
-		
-		// Get review request time
-		firstReviewRequestTime, err := c.getFirstReviewRequestTime(ctx, owner, repo, *pr.Number)
-		if err != nil {
-			c.logger.Warn("Failed to get review request time",
-				slog.String("owner", owner),
-				slog.String("repo", repo),
-				slog.Int("number", *pr.Number),
-				slog.String("error", err.Error()),
-			)
-			// Continue without review request time
+
+		pullRequest := c.convertToDomainEntity(owner, pr)
+		if opts.ExcludeDrafts && pullRequest.Draft {
+			continue
 		}
-		pullRequest.FirstReviewRequestAt = firstReviewRequestTime
-		
-		firstReviewTime, firstApproveTime, err := c.getReviews(ctx, owner, repo, *pr.Number, firstReviewRequestTime)
-		if err != nil {
+
+		if len(opts.Paths) > 0 || opts.FetchFiles {
+			files, err := c.listFiles(ctx, owner, repo, pullRequest.Number)
+			if err != nil {
+				c.logger.Error("Failed to fetch changed files",
+					slog.String("owner", owner),
+					slog.String("repo", repo),
+					slog.Int("number", pullRequest.Number),
+					slog.String("error", err.Error()),
+				)
+				return nil, err
+			}
+			pullRequest.Files = files
+
+			if len(opts.Paths) > 0 && !entity.MatchAnyPath(opts.Paths, files) {
+				c.logger.Debug("Skipping pull request not touching the given paths",
+					slog.Int("number", pullRequest.Number),
+				)
+				continue
+			}
+		}
+
+		if err := c.populateReviewActivity(ctx, owner, repo, pullRequest, opts.Filter); err != nil {
 			return nil, err
 		}
-		pullRequest.FirstReviewAt = firstReviewTime
-		pullRequest.FirstApproveAt = firstApproveTime
+
+		if opts.FetchCommits || opts.FetchReworkStats {
+			commits, err := c.listCommits(ctx, owner, repo, pullRequest.Number)
+			if err != nil {
+				c.logger.Error("Failed to fetch commits",
+					slog.String("owner", owner),
+					slog.String("repo", repo),
+					slog.Int("number", pullRequest.Number),
+					slog.String("error", err.Error()),
+				)
+				return nil, err
+			}
+			pullRequest.CommitLog = commits
+
+			if opts.FetchReworkStats && pullRequest.FirstReviewAt != nil {
+				if err := c.populateCommitStats(ctx, owner, repo, commits, *pullRequest.FirstReviewAt); err != nil {
+					return nil, err
+				}
+			}
+		}
 
 		result = append(result, pullRequest)
 	}
@@ -178,6 +201,55 @@ func (c *Client) List(ctx context.Context, owner, repo string, opts repository.L
 	return result, nil
 }
 
+// searchQuery builds the search query of the pull requests matching the options
+func searchQuery(owner, repo string, opts repository.ListOptions) string {
+	query := fmt.Sprintf("repo:%s/%s is:pr", owner, repo)
+
+	// Add state filter
+	if opts.State != "" {
+		if opts.State == "closed" {
+			query += " is:closed"
+		} else if opts.State == "open" {
+			query += " is:open"
+		}
+	}
+
+	if opts.ExcludeDrafts {
+		query += " draft:false"
+	}
+
+	// Add label, base branch and author filters
+	for _, label := range opts.Labels {
+		query += " label:" + quoteQualifier(label)
+	}
+	if opts.Base != "" {
+		query += " base:" + quoteQualifier(opts.Base)
+	}
+	if len(opts.Authors) == 1 {
+		// Multiple authors are matched client-side, as qualifiers would be combined with AND
+		query += fmt.Sprintf(" author:%s", opts.Authors[0])
+	}
+
+	// Add date filters
+	if opts.Since != nil && opts.Until != nil {
+		// When both are specified, use range syntax
+		query += fmt.Sprintf(" created:%s..%s", opts.Since.Format("2006-01-02"), opts.Until.Format("2006-01-02"))
+	} else if opts.Since != nil {
+		// Only since is specified
+		query += fmt.Sprintf(" created:>=%s", opts.Since.Format("2006-01-02"))
+	} else if opts.Until != nil {
+		// Only until is specified
+		query += fmt.Sprintf(" created:<=%s", opts.Until.Format("2006-01-02"))
+	}
+	return query
+}
+
+// quoteQualifier quotes a qualifier value so that spaces and colons are taken literally.
+// The search syntax has no escapes, so Go quoting would leave backslashes in the value.
+func quoteQualifier(value string) string {
+	return `"` + value + `"`
+}
+
 func (c *Client) Get(ctx context.Context, owner, repo string, number int) (*entity.PullRequest, error) {
 	c.logger.Info("Fetching single pull request",
 		slog.String("owner", owner),
@@ -202,10 +274,21 @@ func (c *Client) Get(ctx context.Context, owner, repo string, number int) (*enti
 		slog.Int("number", number),
 	)
 
-	pullRequest := c.convertToDomainEntity(pr)
+	pullRequest := c.convertToDomainEntity(owner, pr)
+	if err := c.populateReviewActivity(ctx, owner, repo, pullRequest, nil); err != nil {
+		return nil, err
+	}
+
+	return pullRequest, nil
+}
+
+// populateReviewActivity fills in review request, review and response times of the pull request.
+// Reviewers and commenters excluded by the filter are ignored.
+func (c *Client) populateReviewActivity(ctx context.Context, owner, repo string, pullRequest *entity.PullRequest, filter *entity.AccountFilter) error {
+	number := pullRequest.Number
 
 	// Get review request time
-	firstReviewRequestTime, err := c.getFirstReviewRequestTime(ctx, owner, repo, number)
+	requests, err := c.getReviewRequests(ctx, owner, repo, number)
 	if err != nil {
 		c.logger.Warn("Failed to get review request time",
 			slog.String("owner", owner),
@@ -215,26 +298,58 @@ func (c *Client) Get(ctx context.Context, owner, repo string, number int) (*enti
 		)
 		// Continue without review request time
 	}
+	pullRequest.ReviewRequests = requests
+	firstReviewRequestTime := pullRequest.FirstRequestedAt()
 	pullRequest.FirstReviewRequestAt = firstReviewRequestTime
 
-	firstReviewTime, firstApproveTime, err := c.getReviews(ctx, owner, repo, number, firstReviewRequestTime)
+	reviews, err := c.getReviews(ctx, owner, repo, pullRequest, filter)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	pullRequest.FirstReviewAt = firstReviewTime
-	pullRequest.FirstApproveAt = firstApproveTime
+	pullRequest.FirstReviewAt = reviews.firstReviewTime
+	pullRequest.FirstApproveAt = reviews.firstApproveTime
+	pullRequest.FinalApproveAt = reviews.finalApproveTime
+	pullRequest.DismissedApprovals = reviews.dismissedApprovals
+	pullRequest.StaleApprovals = reviews.staleApprovals
+	pullRequest.FirstAutomatedReviewAt = reviews.firstAutomatedReviewTime
+	pullRequest.Approvals = reviews.approvals
+	pullRequest.Reviews = reviews.reviews
+
+	comments, err := c.getComments(ctx, owner, repo, number, filter)
+	if err != nil {
+		return err
+	}
+	pullRequest.Comments = comments
 
-	return pullRequest, nil
+	// The first response is whichever comes first: a formal review or a comment
+	pullRequest.FirstResponseAt = earliest(reviews.firstReviewTime, pullRequest.FirstCommentAt())
+
+	return nil
+}
+
+// reviewTimes holds the times derived from the reviews of a pull request
+type reviewTimes struct {
+	firstReviewTime          *time.Time
+	firstApproveTime         *time.Time
+	finalApproveTime         *time.Time
+	firstAutomatedReviewTime *time.Time
+	approvals                []entity.Approval
+	reviews                  []entity.Review
+	dismissedApprovals       int
+	staleApprovals           int
 }
 
-func (c *Client) getReviews(ctx context.Context, owner, repo string, number int, firstReviewRequestAt *time.Time) (firstReviewTime, firstApproveTime *time.Time, err error) {
+func (c *Client) getReviews(ctx context.Context, owner, repo string, pullRequest *entity.PullRequest, filter *entity.AccountFilter) (*reviewTimes, error) {
+	number := pullRequest.Number
+	firstReviewRequestAt := pullRequest.FirstReviewRequestAt
+
 	c.logger.Debug("Fetching reviews for pull request",
 		slog.String("owner", owner),
 		slog.String("repo", repo),
 		slog.Int("number", number),
 	)
 
-	reviews, _, err := c.client.PullRequests.ListReviews(ctx, owner, repo, number, nil)
+	reviews, err := c.listReviews(ctx, owner, repo, number)
 	if err != nil {
 		c.logger.Error("Failed to fetch reviews",
 			slog.String("owner", owner),
@@ -242,28 +357,68 @@ func (c *Client) getReviews(ctx context.Context, owner, repo string, number int,
 			slog.Int("number", number),
 			slog.String("error", err.Error()),
 		)
-		return nil, nil, err
+		return nil, err
+	}
+
+	// Dismissed reviews are listed with the DISMISSED state,
+	// so dismissed approvals are recognised from the review_dismissed events
+	dismissedApprovals, err := c.getDismissedApprovalIDs(ctx, owner, repo, number)
+	if err != nil {
+		c.logger.Warn("Failed to get dismissed reviews",
+			slog.String("owner", owner),
+			slog.String("repo", repo),
+			slog.Int("number", number),
+			slog.String("error", err.Error()),
+		)
+		// Continue treating dismissed reviews as non-approvals
 	}
 
+	result := &reviewTimes{}
+
 	type reviewInfo struct {
-		state string
-		time  time.Time
+		user      string
+		state     string
+		time      time.Time
+		commitID  string
+		dismissed bool
 	}
 
 	var reviewList []reviewInfo
 	for _, review := range reviews {
 		if review.State != nil && *review.State != "" && *review.State != "PENDING" {
-			// Skip reviews from GitHub Apps (bots)
-			if review.User != nil && review.User.Type != nil && *review.User.Type == "Bot" {
-				c.logger.Debug("Skipping review from GitHub App",
-					slog.String("user", review.User.GetLogin()),
+			submittedAt := review.GetSubmittedAt().Time
+
+			// Automated reviewers are tracked separately from human reviews.
+			// They usually run when the PR is opened, so no review request is required.
+			if filter.IsAutomatedReviewer(review.GetUser().GetLogin()) {
+				c.logger.Debug("Found review from automated reviewer",
+					slog.String("user", review.GetUser().GetLogin()),
 					slog.Int("pr_number", number),
 				)
+				if result.firstAutomatedReviewTime == nil || submittedAt.Before(*result.firstAutomatedReviewTime) {
+					result.firstAutomatedReviewTime = &submittedAt
+				}
 				continue
 			}
-			
-			submittedAt := review.GetSubmittedAt().Time
-			
+
+			// Skip reviews from GitHub Apps (bots) and accounts excluded by the filter
+			if rule := filter.ReviewerExclusion(review.GetUser().GetLogin(), review.GetUser().GetType() == "Bot"); rule != "" {
+				c.logger.Debug("Skipping review from excluded account",
+					slog.String("user", review.GetUser().GetLogin()),
+					slog.String("rule", rule),
+					slog.Int("pr_number", number),
+				)
+				filter.Record(rule)
+				continue
+			}
+
+			result.reviews = append(result.reviews, entity.Review{
+				Reviewer: review.GetUser().GetLogin(),
+				State:    review.GetState(),
+				At:       submittedAt,
+				HasBody:  strings.TrimSpace(review.GetBody()) != "",
+			})
+
 			// Skip reviews that occurred before the review request
 			if firstReviewRequestAt != nil && submittedAt.Before(*firstReviewRequestAt) {
 				c.logger.Debug("Skipping review before review request",
@@ -273,10 +428,20 @@ func (c *Client) getReviews(ctx context.Context, owner, repo string, number int,
 				)
 				continue
 			}
-			
+
+			state := review.GetState()
+			dismissed := false
+			if state == "DISMISSED" && dismissedApprovals[review.GetID()] {
+				state = "APPROVED"
+				dismissed = true
+			}
+
 			reviewList = append(reviewList, reviewInfo{
-				state: review.GetState(),
-				time:  submittedAt,
+				user:      review.GetUser().GetLogin(),
+				state:     state,
+				time:      submittedAt,
+				commitID:  review.GetCommitID(),
+				dismissed: dismissed,
 			})
 		}
 	}
@@ -293,20 +458,108 @@ func (c *Client) getReviews(ctx context.Context, owner, repo string, number int,
 		return reviewList[i].time.Before(reviewList[j].time)
 	})
 
-	// Find first review and first approve
+	// Find first review and first approve.
+	// The first approval counts even if it was dismissed later.
+	approved := make(map[string]bool)
+	var lastValidApproveTime *time.Time
 	for _, r := range reviewList {
-		if firstReviewTime == nil {
-			firstReviewTime = &r.time
+		// The author's own COMMENTED reviews, such as thread replies or notes on their
+		// diff, are not a response from a reviewer
+		if r.user == pullRequest.Author {
+			continue
+		}
+		if result.firstReviewTime == nil {
+			result.firstReviewTime = &r.time
 		}
-		if firstApproveTime == nil && r.state == "APPROVED" {
-			firstApproveTime = &r.time
+		if r.state != "APPROVED" {
+			continue
 		}
+		if result.firstApproveTime == nil {
+			result.firstApproveTime = &r.time
+		}
+		if r.dismissed {
+			result.dismissedApprovals++
+			continue
+		}
+
+		// An approval of an older commit was followed by later pushes
+		if pullRequest.HeadSHA != "" && r.commitID != pullRequest.HeadSHA {
+			result.staleApprovals++
+		} else if result.finalApproveTime == nil {
+			result.finalApproveTime = &r.time
+		}
+		lastValidApproveTime = &r.time
+
+		if !approved[r.user] {
+			approved[r.user] = true
+			result.approvals = append(result.approvals, entity.Approval{
+				Reviewer: r.user,
+				At:       r.time,
+			})
+		}
+	}
+
+	// Without an approval of the final commit, stale approvals were not invalidated by
+	// branch protection, so the latest of them is what enabled the merge
+	if result.finalApproveTime == nil {
+		result.finalApproveTime = lastValidApproveTime
 	}
 
-	return firstReviewTime, firstApproveTime, nil
+	return result, nil
 }
 
-func (c *Client) getFirstReviewRequestTime(ctx context.Context, owner, repo string, number int) (*time.Time, error) {
+func (c *Client) listReviews(ctx context.Context, owner, repo string, number int) ([]*github.PullRequestReview, error) {
+	var allReviews []*github.PullRequestReview
+	opts := &github.ListOptions{PerPage: 100}
+
+	for {
+		reviews, resp, err := c.client.PullRequests.ListReviews(ctx, owner, repo, number, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		allReviews = append(allReviews, reviews...)
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	return allReviews, nil
+}
+
+// getDismissedApprovalIDs returns the IDs of approving reviews that were dismissed
+func (c *Client) getDismissedApprovalIDs(ctx context.Context, owner, repo string, number int) (map[int64]bool, error) {
+	dismissed := make(map[int64]bool)
+	opts := &github.ListOptions{PerPage: 100}
+
+	for {
+		events, resp, err := c.client.Issues.ListIssueEvents(ctx, owner, repo, number, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, event := range events {
+			if event.GetEvent() != "review_dismissed" || event.DismissedReview == nil {
+				continue
+			}
+			if strings.EqualFold(event.DismissedReview.GetState(), "approved") {
+				dismissed[event.DismissedReview.GetReviewID()] = true
+			}
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	return dismissed, nil
+}
+
+// getReviewRequests returns the review request and removal events of the pull request in timeline order
+func (c *Client) getReviewRequests(ctx context.Context, owner, repo string, number int) ([]entity.ReviewRequestEvent, error) {
 	c.logger.Debug("Fetching timeline events for pull request",
 		slog.String("owner", owner),
 		slog.String("repo", repo),
@@ -315,21 +568,21 @@ func (c *Client) getFirstReviewRequestTime(ctx context.Context, owner, repo stri
 
 	var allEvents []*github.Timeline
 	page := 1
-	
+
 	// Fetch all timeline events (handling pagination)
 	for {
 		opts := &github.ListOptions{
 			Page:    page,
 			PerPage: 100,
 		}
-		
+
 		events, resp, err := c.client.Issues.ListIssueTimeline(ctx, owner, repo, number, opts)
 		if err != nil {
 			return nil, err
 		}
-		
+
 		allEvents = append(allEvents, events...)
-		
+
 		if resp.NextPage == 0 {
 			break
 		}
@@ -343,26 +596,25 @@ func (c *Client) getFirstReviewRequestTime(ctx context.Context, owner, repo stri
 		slog.Int("event_count", len(allEvents)),
 	)
 
-	var firstReviewRequestTime *time.Time
+	var requests []entity.ReviewRequestEvent
 	for _, event := range allEvents {
-		// Check for review_requested event
-		if event.Event != nil && *event.Event == "review_requested" {
-			if event.CreatedAt != nil {
-				if firstReviewRequestTime == nil || event.CreatedAt.Before(*firstReviewRequestTime) {
-					firstReviewRequestTime = &event.CreatedAt.Time
-				}
-			}
+		name := event.GetEvent()
+		if (name != "review_requested" && name != "review_request_removed") || event.CreatedAt == nil {
+			continue
 		}
+
+		reviewer := event.GetReviewer().GetLogin()
+		if event.RequestedTeam != nil {
+			reviewer = fmt.Sprintf("@%s/%s", owner, event.RequestedTeam.GetSlug())
+		}
+		requests = append(requests, entity.ReviewRequestEvent{
+			Reviewer: reviewer,
+			At:       event.CreatedAt.Time,
+			Removed:  name == "review_request_removed",
+		})
 	}
 
-	if firstReviewRequestTime != nil {
-		c.logger.Debug("Found first review request time",
-			slog.String("owner", owner),
-			slog.String("repo", repo),
-			slog.Int("number", number),
-			slog.Time("review_requested_at", *firstReviewRequestTime),
-		)
-	} else {
+	if len(requests) == 0 {
 		c.logger.Debug("No review request events found",
 			slog.String("owner", owner),
 			slog.String("repo", repo),
@@ -371,17 +623,40 @@ func (c *Client) getFirstReviewRequestTime(ctx context.Context, owner, repo stri
 		)
 	}
 
-	return firstReviewRequestTime, nil
+	return requests, nil
 }
 
-func (c *Client) convertToDomainEntity(pr *github.PullRequest) *entity.PullRequest {
+// convertToDomainEntity converts the PR of a repository of owner. Requested teams come without
+// their organization, which is the owner of the repository, as in the timeline events.
+func (c *Client) convertToDomainEntity(owner string, pr *github.PullRequest) *entity.PullRequest {
 	pullRequest := &entity.PullRequest{
-		ID:        pr.GetID(),
-		Number:    pr.GetNumber(),
-		Title:     pr.GetTitle(),
-		Author:    pr.GetUser().GetLogin(),
-		State:     pr.GetState(),
-		CreatedAt: pr.GetCreatedAt().Time,
+		ID:         pr.GetID(),
+		Number:     pr.GetNumber(),
+		Title:      pr.GetTitle(),
+		Author:     pr.GetUser().GetLogin(),
+		State:      pr.GetState(),
+		BaseBranch: pr.GetBase().GetRef(),
+		HeadSHA:    pr.GetHead().GetSHA(),
+		Draft:      pr.GetDraft(),
+		CreatedAt:  pr.GetCreatedAt().Time,
+
+		MergeCommitSHA: pr.GetMergeCommitSHA(),
+
+		Additions:    pr.GetAdditions(),
+		Deletions:    pr.GetDeletions(),
+		ChangedFiles: pr.GetChangedFiles(),
+		Commits:      pr.GetCommits(),
+	}
+
+	for _, reviewer := range pr.RequestedReviewers {
+		pullRequest.RequestedReviewers = append(pullRequest.RequestedReviewers, reviewer.GetLogin())
+	}
+	for _, team := range pr.RequestedTeams {
+		pullRequest.RequestedReviewers = append(pullRequest.RequestedReviewers, fmt.Sprintf("@%s/%s", owner, team.GetSlug()))
+	}
+
+	for _, label := range pr.Labels {
+		pullRequest.Labels = append(pullRequest.Labels, label.GetName())
 	}
 
 	if pr.MergedAt != nil {
@@ -395,4 +670,4 @@ func (c *Client) convertToDomainEntity(pr *github.PullRequest) *entity.PullReque
 	}
 
 	return pullRequest
-}
\ No newline at end of file
+}
@@ -0,0 +1,192 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dragoneena12/measure-review-time/domain/entity"
+	"github.com/dragoneena12/measure-review-time/domain/repository"
+	"github.com/google/go-github/v74/github"
+)
+
+// newTestClient returns a client of the API served by handler
+func newTestClient(t *testing.T, handler http.Handler) *Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client := github.NewClient(server.Client())
+	baseURL, err := url.Parse(server.URL + "/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.BaseURL = baseURL
+	return &Client{client: client, logger: slog.New(slog.DiscardHandler)}
+}
+
+func TestSearchQuery(t *testing.T) {
+	since := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		opts repository.ListOptions
+		want string
+	}{
+		{"no filters", repository.ListOptions{}, "repo:o/r is:pr"},
+		{"closed drafts excluded", repository.ListOptions{State: "closed", ExcludeDrafts: true}, "repo:o/r is:pr is:closed draft:false"},
+		{"all states", repository.ListOptions{State: "all"}, "repo:o/r is:pr"},
+		{
+			name: "labels and base are quoted",
+			opts: repository.ListOptions{Labels: []string{"bug", "area: api"}, Base: "release/1.0"},
+			want: `repo:o/r is:pr label:"bug" label:"area: api" base:"release/1.0"`,
+		},
+		{"single author", repository.ListOptions{Authors: []string{"alice"}}, "repo:o/r is:pr author:alice"},
+		{"several authors are matched later", repository.ListOptions{Authors: []string{"alice", "bob"}}, "repo:o/r is:pr"},
+		{"date range", repository.ListOptions{Since: &since, Until: &until}, "repo:o/r is:pr created:2024-01-01..2024-03-31"},
+		{"since only", repository.ListOptions{Since: &since}, "repo:o/r is:pr created:>=2024-01-01"},
+		{"until only", repository.ListOptions{Until: &until}, "repo:o/r is:pr created:<=2024-03-31"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := searchQuery("o", "r", tt.opts); got != tt.want {
+				t.Errorf("searchQuery = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClientGetReviews(t *testing.T) {
+	t0 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(hours float64) time.Time {
+		return t0.Add(time.Duration(hours * float64(time.Hour)))
+	}
+	review := func(id int64, login, state string, hours float64, commitID string) string {
+		userType := "User"
+		if login == "renovate[bot]" {
+			userType = "Bot"
+		}
+		return fmt.Sprintf(`{"id":%d,"user":{"login":%q,"type":%q},"state":%q,"submitted_at":%q,"commit_id":%q}`,
+			id, login, userType, state, at(hours).Format(time.RFC3339), commitID)
+	}
+
+	type want struct {
+		firstReview, firstApprove, finalApprove time.Time
+		dismissed, stale                        int
+		approvals                               []entity.Approval
+		reviews                                 int
+		excluded                                int
+	}
+	tests := []struct {
+		name    string
+		reviews []string
+		events  string
+		want    want
+	}{
+		{
+			name: "dismissed and stale approvals",
+			reviews: []string{
+				// Before the review request: kept as a review, but not a response
+				review(1, "alice", "COMMENTED", 0.5, "old"),
+				review(2, "bob", "APPROVED", 2, "old"),
+				// Dismissed approval, recognised from the review_dismissed event
+				review(3, "carol", "DISMISSED", 3, "old"),
+				// Dismissed request for changes, which is not an approval
+				review(4, "dave", "DISMISSED", 3.5, "old"),
+				review(5, "zed", "COMMENTED", 4, "head"),
+				review(6, "erin", "APPROVED", 5, "head"),
+				review(7, "bob", "APPROVED", 6, "head"),
+				review(8, "frank", "PENDING", 7, "head"),
+				review(9, "renovate[bot]", "APPROVED", 1.5, "head"),
+			},
+			events: `[{"event":"review_dismissed","dismissed_review":{"state":"approved","review_id":3}},` +
+				`{"event":"review_dismissed","dismissed_review":{"state":"changes_requested","review_id":4}}]`,
+			want: want{
+				firstReview:  at(2),
+				firstApprove: at(2),
+				finalApprove: at(5),
+				dismissed:    1,
+				stale:        1,
+				approvals:    []entity.Approval{{Reviewer: "bob", At: at(2)}, {Reviewer: "erin", At: at(5)}},
+				reviews:      7,
+				excluded:     1,
+			},
+		},
+		{
+			// Without an approval of the head commit, the latest stale one enabled the merge
+			name: "only stale approvals",
+			reviews: []string{
+				review(1, "bob", "APPROVED", 2, "old"),
+				review(2, "carol", "APPROVED", 3, "older"),
+			},
+			events: `[]`,
+			want: want{
+				firstReview:  at(2),
+				firstApprove: at(2),
+				finalApprove: at(3),
+				stale:        2,
+				approvals:    []entity.Approval{{Reviewer: "bob", At: at(2)}, {Reviewer: "carol", At: at(3)}},
+				reviews:      2,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux := http.NewServeMux()
+			mux.HandleFunc("GET /repos/o/r/pulls/1/reviews", func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprintf(w, "[%s]", strings.Join(tt.reviews, ","))
+			})
+			mux.HandleFunc("GET /repos/o/r/issues/1/events", func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, tt.events)
+			})
+			c := newTestClient(t, mux)
+
+			pr := &entity.PullRequest{Number: 1, Author: "zed", HeadSHA: "head", FirstReviewRequestAt: ptr(at(1))}
+			filter := &entity.AccountFilter{}
+			got, err := c.getReviews(context.Background(), "o", "r", pr, filter)
+			if err != nil {
+				t.Fatalf("getReviews returned error: %v", err)
+			}
+
+			for _, c := range []struct {
+				name string
+				got  *time.Time
+				want time.Time
+			}{
+				{"first review", got.firstReviewTime, tt.want.firstReview},
+				{"first approval", got.firstApproveTime, tt.want.firstApprove},
+				{"final approval", got.finalApproveTime, tt.want.finalApprove},
+			} {
+				if c.got == nil || !c.got.Equal(c.want) {
+					t.Errorf("%s at %v, want %v", c.name, c.got, c.want)
+				}
+			}
+			if got.dismissedApprovals != tt.want.dismissed || got.staleApprovals != tt.want.stale {
+				t.Errorf("dismissed, stale approvals = %d, %d, want %d, %d", got.dismissedApprovals, got.staleApprovals, tt.want.dismissed, tt.want.stale)
+			}
+			if fmt.Sprint(got.approvals) != fmt.Sprint(tt.want.approvals) {
+				t.Errorf("approvals = %v, want %v", got.approvals, tt.want.approvals)
+			}
+			if len(got.reviews) != tt.want.reviews {
+				t.Errorf("got %d reviews, want %d: %+v", len(got.reviews), tt.want.reviews, got.reviews)
+			}
+			excluded := 0
+			for _, e := range filter.Exclusions() {
+				excluded += e.Count
+			}
+			if excluded != tt.want.excluded {
+				t.Errorf("%d reviews excluded by the filter, want %d", excluded, tt.want.excluded)
+			}
+		})
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
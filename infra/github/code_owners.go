@@ -0,0 +1,86 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/dragoneena12/measure-review-time/domain/entity"
+	"github.com/dragoneena12/measure-review-time/infra/codeowners"
+	"github.com/google/go-github/v74/github"
+)
+
+// codeOwnersPaths are the locations GitHub looks for a CODEOWNERS file, in order
+var codeOwnersPaths = []string{".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS"}
+
+func (c *Client) GetCodeOwners(ctx context.Context, owner, repo string) (*entity.CodeOwners, error) {
+	for _, path := range codeOwnersPaths {
+		c.logger.Debug("Fetching CODEOWNERS",
+			slog.String("owner", owner),
+			slog.String("repo", repo),
+			slog.String("path", path),
+		)
+
+		file, _, _, err := c.client.Repositories.GetContents(ctx, owner, repo, path, nil)
+		if err != nil {
+			var errResp *github.ErrorResponse
+			if errors.As(err, &errResp) && errResp.Response.StatusCode == http.StatusNotFound {
+				continue
+			}
+			c.logger.Error("Failed to fetch CODEOWNERS",
+				slog.String("owner", owner),
+				slog.String("repo", repo),
+				slog.String("path", path),
+				slog.String("error", err.Error()),
+			)
+			return nil, err
+		}
+
+		content, err := file.GetContent()
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode %s: %w", path, err)
+		}
+
+		c.logger.Info("Fetched CODEOWNERS",
+			slog.String("owner", owner),
+			slog.String("repo", repo),
+			slog.String("path", path),
+		)
+		return codeowners.Parse(strings.NewReader(content))
+	}
+
+	return nil, fmt.Errorf("no CODEOWNERS file found in %s/%s", owner, repo)
+}
+
+func (c *Client) ListTeamMembers(ctx context.Context, org, slug string) ([]string, error) {
+	var members []string
+	opts := &github.TeamListTeamMembersOptions{
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+
+	for {
+		users, resp, err := c.client.Teams.ListTeamMembersBySlug(ctx, org, slug, opts)
+		if err != nil {
+			c.logger.Warn("Failed to list team members",
+				slog.String("org", org),
+				slog.String("team", slug),
+				slog.String("error", err.Error()),
+			)
+			return nil, err
+		}
+
+		for _, u := range users {
+			members = append(members, u.GetLogin())
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	return members, nil
+}
@@ -0,0 +1,139 @@
+package github
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"time"
+
+	"github.com/dragoneena12/measure-review-time/domain/entity"
+	"github.com/google/go-github/v74/github"
+)
+
+// getComments returns the issue comments and review comments on the pull request in time order,
+// skipping bots, automated reviewers and accounts excluded by the filter
+func (c *Client) getComments(ctx context.Context, owner, repo string, number int, filter *entity.AccountFilter) ([]entity.Comment, error) {
+	c.logger.Debug("Fetching comments for pull request",
+		slog.String("owner", owner),
+		slog.String("repo", repo),
+		slog.Int("number", number),
+	)
+
+	var comments []entity.Comment
+	consider := func(user *github.User, createdAt *github.Timestamp, inline bool) {
+		if user == nil || createdAt == nil {
+			return
+		}
+		// Comments of automated reviewers are not a human response, as for their reviews
+		if filter.IsAutomatedReviewer(user.GetLogin()) {
+			return
+		}
+		if rule := filter.ReviewerExclusion(user.GetLogin(), user.GetType() == "Bot"); rule != "" {
+			filter.Record(rule)
+			return
+		}
+		comments = append(comments, entity.Comment{
+			Author: user.GetLogin(),
+			At:     createdAt.Time,
+			Inline: inline,
+		})
+	}
+
+	issueComments, err := c.listIssueComments(ctx, owner, repo, number)
+	if err != nil {
+		c.logger.Error("Failed to fetch issue comments",
+			slog.String("owner", owner),
+			slog.String("repo", repo),
+			slog.Int("number", number),
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+	for _, comment := range issueComments {
+		consider(comment.User, comment.CreatedAt, false)
+	}
+
+	reviewComments, err := c.listReviewComments(ctx, owner, repo, number)
+	if err != nil {
+		c.logger.Error("Failed to fetch review comments",
+			slog.String("owner", owner),
+			slog.String("repo", repo),
+			slog.Int("number", number),
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+	for _, comment := range reviewComments {
+		consider(comment.User, comment.CreatedAt, true)
+	}
+
+	c.logger.Debug("Successfully fetched comments",
+		slog.String("owner", owner),
+		slog.String("repo", repo),
+		slog.Int("number", number),
+		slog.Int("issue_comment_count", len(issueComments)),
+		slog.Int("review_comment_count", len(reviewComments)),
+	)
+
+	sort.SliceStable(comments, func(i, j int) bool {
+		return comments[i].At.Before(comments[j].At)
+	})
+	return comments, nil
+}
+
+func (c *Client) listIssueComments(ctx context.Context, owner, repo string, number int) ([]*github.IssueComment, error) {
+	var allComments []*github.IssueComment
+	opts := &github.IssueListCommentsOptions{
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+
+	for {
+		comments, resp, err := c.client.Issues.ListComments(ctx, owner, repo, number, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		allComments = append(allComments, comments...)
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	return allComments, nil
+}
+
+func (c *Client) listReviewComments(ctx context.Context, owner, repo string, number int) ([]*github.PullRequestComment, error) {
+	var allComments []*github.PullRequestComment
+	opts := &github.PullRequestListCommentsOptions{
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+
+	for {
+		comments, resp, err := c.client.PullRequests.ListComments(ctx, owner, repo, number, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		allComments = append(allComments, comments...)
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	return allComments, nil
+}
+
+// earliest returns the earlier of two optional times
+func earliest(a, b *time.Time) *time.Time {
+	if a == nil {
+		return b
+	}
+	if b == nil || a.Before(*b) {
+		return a
+	}
+	return b
+}
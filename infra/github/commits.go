@@ -0,0 +1,62 @@
+package github
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/dragoneena12/measure-review-time/domain/entity"
+	"github.com/google/go-github/v74/github"
+)
+
+// listCommits returns the commits of the pull request in the order GitHub lists them
+func (c *Client) listCommits(ctx context.Context, owner, repo string, number int) ([]entity.Commit, error) {
+	var commits []entity.Commit
+	opts := &github.ListOptions{PerPage: 100}
+
+	for {
+		repoCommits, resp, err := c.client.PullRequests.ListCommits(ctx, owner, repo, number, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, rc := range repoCommits {
+			commits = append(commits, entity.Commit{
+				SHA:         rc.GetSHA(),
+				AuthoredAt:  rc.GetCommit().GetAuthor().GetDate().Time,
+				CommittedAt: rc.GetCommit().GetCommitter().GetDate().Time,
+			})
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	return commits, nil
+}
+
+// populateCommitStats fills in the changed lines of the commits made after the given time,
+// fetching each of them, as the commit list does not include stats
+func (c *Client) populateCommitStats(ctx context.Context, owner, repo string, commits []entity.Commit, after time.Time) error {
+	for i := range commits {
+		if !commits[i].CommittedAt.After(after) {
+			continue
+		}
+
+		commit, _, err := c.client.Repositories.GetCommit(ctx, owner, repo, commits[i].SHA, nil)
+		if err != nil {
+			c.logger.Error("Failed to get commit",
+				slog.String("owner", owner),
+				slog.String("repo", repo),
+				slog.String("sha", commits[i].SHA),
+				slog.String("error", err.Error()),
+			)
+			return err
+		}
+		commits[i].Additions = commit.GetStats().GetAdditions()
+		commits[i].Deletions = commit.GetStats().GetDeletions()
+	}
+	return nil
+}
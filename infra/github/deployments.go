@@ -0,0 +1,161 @@
+package github
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/dragoneena12/measure-review-time/domain/entity"
+	"github.com/google/go-github/v74/github"
+)
+
+func (c *Client) ListDeployments(ctx context.Context, owner, repo, environment string, since time.Time) ([]entity.Deployment, error) {
+	var deployments []entity.Deployment
+	opts := &github.DeploymentsListOptions{
+		Environment: environment,
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+
+	// Deployments are listed newest first
+	for {
+		page, resp, err := c.client.Repositories.ListDeployments(ctx, owner, repo, opts)
+		if err != nil {
+			c.logger.Error("Failed to list deployments",
+				slog.String("owner", owner),
+				slog.String("repo", repo),
+				slog.String("environment", environment),
+				slog.String("error", err.Error()),
+			)
+			return nil, err
+		}
+
+		done := false
+		for _, d := range page {
+			if d.GetCreatedAt().Before(since) {
+				done = true
+				break
+			}
+
+			succeededAt, err := c.getDeploymentSuccessTime(ctx, owner, repo, d.GetID())
+			if err != nil {
+				return nil, err
+			}
+			if succeededAt == nil {
+				continue
+			}
+			deployments = append(deployments, entity.Deployment{Ref: d.GetSHA(), At: *succeededAt})
+		}
+
+		if done || resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	c.logger.Info("Fetched deployments",
+		slog.String("owner", owner),
+		slog.String("repo", repo),
+		slog.String("environment", environment),
+		slog.Int("count", len(deployments)),
+	)
+
+	entity.SortDeployments(deployments)
+	return deployments, nil
+}
+
+// getDeploymentSuccessTime returns when the deployment first reported success, or nil if it never did
+func (c *Client) getDeploymentSuccessTime(ctx context.Context, owner, repo string, id int64) (*time.Time, error) {
+	var succeededAt *time.Time
+	opts := &github.ListOptions{PerPage: 100}
+
+	for {
+		statuses, resp, err := c.client.Repositories.ListDeploymentStatuses(ctx, owner, repo, id, opts)
+		if err != nil {
+			c.logger.Error("Failed to list deployment statuses",
+				slog.String("owner", owner),
+				slog.String("repo", repo),
+				slog.Int64("deployment_id", id),
+				slog.String("error", err.Error()),
+			)
+			return nil, err
+		}
+
+		for _, s := range statuses {
+			if s.GetState() != "success" {
+				continue
+			}
+			if at := s.GetCreatedAt().Time; succeededAt == nil || at.Before(*succeededAt) {
+				succeededAt = &at
+			}
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	return succeededAt, nil
+}
+
+func (c *Client) ListReleases(ctx context.Context, owner, repo string, since time.Time) ([]entity.Deployment, error) {
+	var releases []entity.Deployment
+	opts := &github.ListOptions{PerPage: 100}
+
+	// Releases are listed newest first
+	for {
+		page, resp, err := c.client.Repositories.ListReleases(ctx, owner, repo, opts)
+		if err != nil {
+			c.logger.Error("Failed to list releases",
+				slog.String("owner", owner),
+				slog.String("repo", repo),
+				slog.String("error", err.Error()),
+			)
+			return nil, err
+		}
+
+		done := false
+		for _, r := range page {
+			if r.GetDraft() || r.PublishedAt == nil {
+				continue
+			}
+			if r.GetPublishedAt().Before(since) {
+				done = true
+				break
+			}
+			releases = append(releases, entity.Deployment{Ref: r.GetTagName(), At: r.GetPublishedAt().Time})
+		}
+
+		if done || resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	c.logger.Info("Fetched releases",
+		slog.String("owner", owner),
+		slog.String("repo", repo),
+		slog.Int("count", len(releases)),
+	)
+
+	entity.SortDeployments(releases)
+	return releases, nil
+}
+
+func (c *Client) ContainsCommit(ctx context.Context, owner, repo, ref, sha string) (bool, error) {
+	comparison, _, err := c.client.Repositories.CompareCommits(ctx, owner, repo, sha, ref, &github.ListOptions{PerPage: 1})
+	if err != nil {
+		c.logger.Error("Failed to compare commits",
+			slog.String("owner", owner),
+			slog.String("repo", repo),
+			slog.String("base", sha),
+			slog.String("head", ref),
+			slog.String("error", err.Error()),
+		)
+		return false, err
+	}
+
+	// ref contains sha when it is ahead of or identical to it
+	status := comparison.GetStatus()
+	return status == "ahead" || status == "identical", nil
+}
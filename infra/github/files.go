@@ -0,0 +1,31 @@
+package github
+
+import (
+	"context"
+
+	"github.com/google/go-github/v74/github"
+)
+
+// listFiles returns the paths of the files changed by the pull request
+func (c *Client) listFiles(ctx context.Context, owner, repo string, number int) ([]string, error) {
+	var files []string
+	opts := &github.ListOptions{PerPage: 100}
+
+	for {
+		commitFiles, resp, err := c.client.PullRequests.ListFiles(ctx, owner, repo, number, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, f := range commitFiles {
+			files = append(files, f.GetFilename())
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	return files, nil
+}
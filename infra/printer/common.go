@@ -1,7 +1,13 @@
 package printer
 
 import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
 	"time"
+
+	"github.com/dragoneena12/measure-review-time/domain/entity"
 )
 
 func formatDuration(d time.Duration) int {
@@ -14,3 +20,154 @@ func truncateString(s string, maxLen int) string {
 	}
 	return s[:maxLen-3] + "..."
 }
+
+// approvers returns the logins of the approving reviewers in approval order
+func approvers(pr *entity.PullRequest) []string {
+	logins := make([]string, 0, len(pr.Approvals))
+	for _, a := range pr.Approvals {
+		logins = append(logins, a.Reviewer)
+	}
+	return logins
+}
+
+// perPR averages a total over the PRs, or returns zero without PRs
+func perPR(total, prs int) float64 {
+	if prs == 0 {
+		return 0
+	}
+	return float64(total) / float64(prs)
+}
+
+// rubberStampReasons joins the reasons an approval was flagged
+func rubberStampReasons(reasons []entity.RubberStampReason) string {
+	names := make([]string, 0, len(reasons))
+	for _, r := range reasons {
+		names = append(names, string(r))
+	}
+	return strings.Join(names, ";")
+}
+
+// share is the part of the total in percent, or zero when the total is zero
+func share(part, total time.Duration) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(part) * 100 / float64(total)
+}
+
+// percent is the count out of the total in percent, or zero when the total is zero
+func percent(count, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(count) * 100 / float64(total)
+}
+
+// ballInCourtDurations returns the waiting time buckets, or nils when not measured
+func ballInCourtDurations(b *entity.BallInCourt) (reviewer, author, idle *time.Duration) {
+	if b == nil {
+		return nil, nil, nil
+	}
+	return &b.ReviewerWait, &b.AuthorWait, &b.IdleAfterApproval
+}
+
+// feedbackSummary summarizes the author's response times to the feedback on a PR
+func feedbackSummary(responses []entity.FeedbackResponse) entity.DurationSummary {
+	var durations []time.Duration
+	for _, f := range responses {
+		if d := f.ResponseTime(); d != nil {
+			durations = append(durations, *d)
+		}
+	}
+	return entity.Summarize(durations)
+}
+
+// authorResponses lists the per-author rows followed by the whole repository
+func authorResponses(report *entity.Report) []entity.AuthorResponsiveness {
+	if report.RepoAuthorResponse == nil {
+		return nil
+	}
+	all := *report.RepoAuthorResponse
+	all.Author = fmt.Sprintf("(all %s/%s)", report.Owner, report.Repo)
+	return append(append([]entity.AuthorResponsiveness{}, report.AuthorResponses...), all)
+}
+
+// outlierMetrics lists the PRs whose metric fell outside the fence
+func outlierMetrics(report *entity.Report, fence entity.OutlierFence) []*entity.ReviewMetrics {
+	var outliers []*entity.ReviewMetrics
+	for _, m := range report.Metrics {
+		if m.IsOutlier(fence.Metric.Key) {
+			outliers = append(outliers, m)
+		}
+	}
+	return outliers
+}
+
+// outlierNames names the metrics detected as outliers for the PR
+func outlierNames(m *entity.ReviewMetrics) []string {
+	names := make([]string, 0, len(m.Outliers))
+	for _, metric := range entity.DurationMetrics {
+		if m.IsOutlier(metric.Key) {
+			names = append(names, metric.Name)
+		}
+	}
+	return names
+}
+
+// outlierMethodName describes how the fences of an outlier method are drawn
+func outlierMethodName(method entity.OutlierMethod) string {
+	if method == entity.OutlierRobustZ {
+		return "robust z-score > 3.5"
+	}
+	return "IQR fences"
+}
+
+// significanceLevel is the adjusted p-value below which a difference between groups is called significant
+const significanceLevel = 0.05
+
+// effectMagnitude names the size of a rank-biserial correlation with the usual thresholds
+func effectMagnitude(r float64) string {
+	switch r = math.Abs(r); {
+	case r < 0.1:
+		return "negligible"
+	case r < 0.3:
+		return "small"
+	case r < 0.5:
+		return "medium"
+	default:
+		return "large"
+	}
+}
+
+// groupExclusion is how many items a filter rule excluded in each of the compared groups
+type groupExclusion struct {
+	Rule     string
+	Baseline int
+	Current  int
+}
+
+// groupExclusions lines up the exclusions of groups measured separately by rule
+func groupExclusions(report *entity.ComparisonReport) []groupExclusion {
+	byRule := make(map[string]*groupExclusion)
+	var rules []string
+	count := func(exclusions []entity.Exclusion, add func(*groupExclusion, int)) {
+		for _, e := range exclusions {
+			g, ok := byRule[e.Rule]
+			if !ok {
+				g = &groupExclusion{Rule: e.Rule}
+				byRule[e.Rule] = g
+				rules = append(rules, e.Rule)
+			}
+			add(g, e.Count)
+		}
+	}
+	count(report.Baseline.Exclusions, func(g *groupExclusion, n int) { g.Baseline += n })
+	count(report.Current.Exclusions, func(g *groupExclusion, n int) { g.Current += n })
+
+	sort.Strings(rules)
+	result := make([]groupExclusion, 0, len(rules))
+	for _, rule := range rules {
+		result = append(result, *byRule[rule])
+	}
+	return result
+}
@@ -5,6 +5,7 @@ import (
 	"io"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/dragoneena12/measure-review-time/domain/entity"
 	"github.com/dragoneena12/measure-review-time/domain/repository"
@@ -20,34 +21,401 @@ func NewCSVPrinter() repository.Printer {
 	}
 }
 
-func (p *CSVPrinter) Print(owner, repo string, metrics []*entity.ReviewMetrics) error {
-	fmt.Fprintln(p.writer, "PR_Number,Title,Author,Created_At,Time_To_Review_Minutes,Time_To_Approve_Minutes")
+func (p *CSVPrinter) Print(report *entity.Report) error {
+	fmt.Fprintln(p.writer, strings.Join([]string{
+		"PR_Number", "Title", "Author", "Outcome", "Created_At",
+		"Time_To_Review_Minutes", "Time_To_Approve_Minutes", "Time_To_Request_Minutes", "Review_To_Approve_Minutes",
+		"Approve_To_Merge_Minutes", "Lifetime_Minutes", "Time_To_First_Response_Minutes", "Time_To_Automated_Review_Minutes",
+		"Size", "Additions", "Deletions", "Changed_Files", "Commits",
+		"Areas", "Code_Owner_Approved",
+		"Time_To_Final_Approve_Minutes", "Dismissed_Approvals", "Stale_Approvals",
+		"Required_Approvals", "Time_To_Required_Approvals_Minutes", "Approvers",
+		"Coding_Time_Minutes", "Merge_To_Deploy_Minutes", "Commit_To_Deploy_Minutes",
+		"Rework_Commits", "Rework_Additions", "Rework_Deletions", "Review_To_Last_Push_Minutes",
+		"Review_Comments", "Review_Commenters", "Review_Comments_Per_100_Lines",
+		"Reviewer_Wait_Minutes", "Author_Wait_Minutes", "Idle_After_Approval_Minutes",
+		"Feedback_Reviews", "Median_Author_Response_Minutes", "Outliers",
+	}, ","))
 
-	for _, metric := range metrics {
+	for _, metric := range report.Metrics {
 		pr := metric.PullRequest
+		reviewerWait, authorWait, idle := ballInCourtDurations(metric.BallInCourt)
+		feedback := feedbackSummary(metric.FeedbackResponses)
 
-		timeToReview := ""
-		if metric.TimeToReview != nil {
-			timeToReview = fmt.Sprintf("%d", formatDuration(*metric.TimeToReview))
+		title := strings.ReplaceAll(pr.Title, ",", ";")
+
+		fmt.Fprintln(p.writer, strings.Join([]string{
+			fmt.Sprintf("%d", pr.Number),
+			csvQuote(title),
+			pr.Author,
+			string(metric.Outcome),
+			pr.CreatedAt.Format("2006-01-02 15:04:05"),
+			csvDuration(metric.TimeToReview),
+			csvDuration(metric.TimeToApprove),
+			csvDuration(metric.TimeToRequest),
+			csvDuration(metric.ReviewToApprove),
+			csvDuration(metric.ApproveToMerge),
+			csvDuration(metric.TotalDuration),
+			csvDuration(metric.TimeToFirstResponse),
+			csvDuration(metric.TimeToAutomatedReview),
+			string(metric.Size),
+			fmt.Sprintf("%d", pr.Additions),
+			fmt.Sprintf("%d", pr.Deletions),
+			fmt.Sprintf("%d", pr.ChangedFiles),
+			fmt.Sprintf("%d", pr.Commits),
+			csvQuote(strings.Join(metric.Areas, ";")),
+			csvBool(metric.CodeOwnerApproved),
+			csvDuration(metric.TimeToFinalApprove),
+			fmt.Sprintf("%d", pr.DismissedApprovals),
+			fmt.Sprintf("%d", pr.StaleApprovals),
+			fmt.Sprintf("%d", max(pr.RequiredApprovals, 1)),
+			csvDuration(metric.TimeToRequiredApprovals),
+			csvQuote(strings.Join(approvers(pr), ";")),
+			csvDuration(metric.CodingTime),
+			csvDuration(metric.MergeToDeploy),
+			csvDuration(metric.CommitToDeploy),
+			csvRework(metric.Rework, func(r *entity.Rework) int { return r.Commits }),
+			csvRework(metric.Rework, func(r *entity.Rework) int { return r.Additions }),
+			csvRework(metric.Rework, func(r *entity.Rework) int { return r.Deletions }),
+			csvDuration(metric.ReviewToLastPush),
+			fmt.Sprintf("%d", metric.ReviewDepth.Comments),
+			fmt.Sprintf("%d", metric.ReviewDepth.Commenters),
+			fmt.Sprintf("%.2f", metric.ReviewDepth.CommentsPer100Lines),
+			csvDuration(reviewerWait),
+			csvDuration(authorWait),
+			csvDuration(idle),
+			fmt.Sprintf("%d", len(metric.FeedbackResponses)),
+			csvSummary(feedback, feedback.Median),
+			csvQuote(strings.Join(metric.Outliers, ";")),
+		}, ","))
+	}
+
+	p.printSummary(report.Summary)
+	p.printOutliers(report)
+	p.printSurvival(report.Survival)
+	p.printOutcomes(report.Outcomes)
+	p.printLatencyGroups("Size_Bucket", report.SizeBuckets)
+	p.printAreas(report.Areas)
+	p.printRework(report.Rework)
+	p.printTeamWaits(report.TeamWaits)
+	p.printAuthorResponses(report)
+	p.printReviewerLoad(report.ReviewerLoad)
+	p.printRubberStamps(report)
+	p.printExclusions(report.Exclusions)
+	return nil
+}
+
+// printSummary appends the distribution of each duration metric, then the share of PRs
+// without a review or approval, as two sections
+func (p *CSVPrinter) printSummary(summary *entity.Summary) {
+	if summary == nil {
+		return
+	}
+
+	fmt.Fprintln(p.writer)
+	fmt.Fprintln(p.writer, "Metric,Count,Mean_Minutes,Median_Minutes,P75_Minutes,P90_Minutes,P95_Minutes,Min_Minutes,Max_Minutes,Excluded_Outliers")
+	for _, m := range summary.Metrics {
+		s := m.DurationSummary
+		fmt.Fprintf(p.writer, "%s,%d,%s,%s,%s,%s,%s,%s,%s,%d\n",
+			m.Metric.Key,
+			s.Count,
+			csvSummary(s, s.Mean),
+			csvSummary(s, s.Median),
+			csvSummary(s, s.P75),
+			csvSummary(s, s.P90),
+			csvSummary(s, s.P95),
+			csvSummary(s, s.Min),
+			csvSummary(s, s.Max),
+			m.Outliers,
+		)
+	}
+
+	fmt.Fprintln(p.writer)
+	fmt.Fprintln(p.writer, "Outcome,PR_Count,Unreviewed_Count,Unreviewed_Percent,Unapproved_Count,Unapproved_Percent")
+	fmt.Fprintf(p.writer, "%s,%d,%d,%.1f,%d,%.1f\n",
+		outcomeLabel(summary.Outcome),
+		summary.PRs,
+		summary.Unreviewed,
+		percent(summary.Unreviewed, summary.PRs),
+		summary.Unapproved,
+		percent(summary.Unapproved, summary.PRs),
+	)
+}
+
+// printOutliers appends one row per outlier with the fence of its metric
+func (p *CSVPrinter) printOutliers(report *entity.Report) {
+	detection := report.Outliers
+	if detection == nil {
+		return
+	}
+
+	fmt.Fprintln(p.writer)
+	fmt.Fprintln(p.writer, "Outlier_Metric,Method,Lower_Minutes,Upper_Minutes,PR_Number,Value_Minutes")
+	for _, fence := range detection.Fences {
+		for _, m := range outlierMetrics(report, fence) {
+			fmt.Fprintf(p.writer, "%s,%s,%s,%s,%d,%s\n",
+				fence.Metric.Key,
+				detection.Method,
+				csvDuration(&fence.Lower),
+				csvDuration(&fence.Upper),
+				m.PullRequest.Number,
+				csvDuration(fence.Metric.Of(m)),
+			)
 		}
+	}
+}
 
-		timeToApprove := ""
-		if metric.TimeToApprove != nil {
-			timeToApprove = fmt.Sprintf("%d", formatDuration(*metric.TimeToApprove))
+// printSurvival appends the share of PRs still waiting at each horizon, as a fraction
+func (p *CSVPrinter) printSurvival(survival []entity.MetricSurvival) {
+	if len(survival) == 0 {
+		return
+	}
+
+	header := []string{"Survival_Metric", "PR_Count", "Event_Count", "Censored_Count"}
+	for _, h := range entity.SurvivalHorizons {
+		header = append(header, "Still_Waiting_"+h.Label)
+	}
+	fmt.Fprintln(p.writer)
+	fmt.Fprintln(p.writer, strings.Join(append(header, "Median_Minutes"), ","))
+	for _, s := range survival {
+		c := s.Curve
+		row := []string{
+			s.Metric.Key,
+			fmt.Sprintf("%d", c.Observations),
+			fmt.Sprintf("%d", c.Events),
+			fmt.Sprintf("%d", c.Observations-c.Events),
 		}
+		for _, h := range entity.SurvivalHorizons {
+			row = append(row, csvProbability(c.StillWaiting(h.After)))
+		}
+		fmt.Fprintln(p.writer, strings.Join(append(row, csvDuration(c.Median())), ","))
+	}
+}
 
-		title := strings.ReplaceAll(pr.Title, ",", ";")
-		title = strings.ReplaceAll(title, "\"", "'")
+// printLatencyGroups appends aggregated latency as a separate section after a blank line
+func (p *CSVPrinter) printLatencyGroups(keyHeader string, groups []entity.LatencyGroup) {
+	if len(groups) == 0 {
+		return
+	}
 
-		fmt.Fprintf(p.writer, "%d,\"%s\",%s,%s,%s,%s\n",
-			pr.Number,
-			title,
-			pr.Author,
-			pr.CreatedAt.Format("2006-01-02 15:04:05"),
-			timeToReview,
-			timeToApprove,
+	fmt.Fprintln(p.writer)
+	fmt.Fprintf(p.writer, "%s,%s\n", keyHeader, csvLatencyHeader)
+	for _, g := range groups {
+		fmt.Fprintf(p.writer, "%s,%s\n", csvQuote(g.Key), csvLatencyCells(g))
+	}
+}
+
+func (p *CSVPrinter) printOutcomes(groups []entity.LatencyGroup) {
+	if len(groups) == 0 {
+		return
+	}
+
+	fmt.Fprintln(p.writer)
+	fmt.Fprintln(p.writer, csvLatencyHeader)
+	for _, g := range groups {
+		fmt.Fprintln(p.writer, csvLatencyCells(g))
+	}
+}
+
+func (p *CSVPrinter) printAreas(areas []entity.AreaLatency) {
+	if len(areas) == 0 {
+		return
+	}
+
+	fmt.Fprintln(p.writer)
+	fmt.Fprintf(p.writer, "Area,%s,Approved_Count,Code_Owner_Approved_Count\n", csvLatencyHeader)
+	for _, a := range areas {
+		fmt.Fprintf(p.writer, "%s,%s,%d,%d\n", csvQuote(a.Key), csvLatencyCells(a.LatencyGroup), a.Approved, a.CodeOwnerApproved)
+	}
+}
+
+func (p *CSVPrinter) printRework(groups []entity.ReworkGroup) {
+	if len(groups) == 0 {
+		return
+	}
+
+	fmt.Fprintln(p.writer)
+	fmt.Fprintln(p.writer, "Team,Reviewed_PR_Count,Reworked_PR_Count,Rework_Commits,Rework_Lines,Median_Review_To_Last_Push_Minutes,Mean_Review_To_Last_Push_Minutes")
+	for _, g := range groups {
+		fmt.Fprintf(p.writer, "%s,%d,%d,%d,%d,%s,%s\n",
+			csvQuote(g.Team),
+			g.PRs,
+			g.Reworked,
+			g.Commits,
+			g.Lines,
+			csvSummary(g.ReviewToLastPush, g.ReviewToLastPush.Median),
+			csvSummary(g.ReviewToLastPush, g.ReviewToLastPush.Mean),
 		)
 	}
+}
 
-	return nil
-}
\ No newline at end of file
+func (p *CSVPrinter) printTeamWaits(groups []entity.TeamWait) {
+	if len(groups) == 0 {
+		return
+	}
+
+	fmt.Fprintln(p.writer)
+	fmt.Fprintln(p.writer, "Team,PR_Count,Median_Reviewer_Wait_Minutes,Median_Author_Wait_Minutes,Median_Idle_After_Approval_Minutes,Total_Reviewer_Wait_Minutes,Total_Author_Wait_Minutes,Total_Idle_After_Approval_Minutes")
+	for _, g := range groups {
+		fmt.Fprintf(p.writer, "%s,%d,%s,%s,%s,%d,%d,%d\n",
+			csvQuote(g.Team),
+			g.PRs,
+			csvSummary(g.ReviewerWait, g.ReviewerWait.Median),
+			csvSummary(g.AuthorWait, g.AuthorWait.Median),
+			csvSummary(g.IdleAfterApproval, g.IdleAfterApproval.Median),
+			formatDuration(g.Total.ReviewerWait),
+			formatDuration(g.Total.AuthorWait),
+			formatDuration(g.Total.IdleAfterApproval),
+		)
+	}
+}
+
+func (p *CSVPrinter) printAuthorResponses(report *entity.Report) {
+	rows := authorResponses(report)
+	if len(rows) == 0 {
+		return
+	}
+
+	fmt.Fprintln(p.writer)
+	fmt.Fprintln(p.writer, "Responding_Author,PR_Count,Feedback_Count,Unanswered_Count,Median_Response_Minutes,Mean_Response_Minutes")
+	for _, a := range rows {
+		fmt.Fprintf(p.writer, "%s,%d,%d,%d,%s,%s\n",
+			csvQuote(a.Author),
+			a.PRs,
+			a.Feedback,
+			a.Unanswered,
+			csvSummary(a.ResponseTime, a.ResponseTime.Median),
+			csvSummary(a.ResponseTime, a.ResponseTime.Mean),
+		)
+	}
+}
+
+// printReviewerLoad appends the load per reviewer, followed by the daily queue of each reviewer
+func (p *CSVPrinter) printReviewerLoad(loads []entity.ReviewerLoad) {
+	if len(loads) == 0 {
+		return
+	}
+
+	fmt.Fprintln(p.writer)
+	fmt.Fprintln(p.writer, "Reviewer,Request_Count,Max_Queue,Mean_Daily_Max_Queue,Average_Queue,Median_Response_Minutes,Mean_Response_Minutes,Load_Response_Correlation")
+	for _, l := range loads {
+		correlation := ""
+		if l.LoadCorrelation != nil {
+			correlation = fmt.Sprintf("%.3f", *l.LoadCorrelation)
+		}
+		fmt.Fprintf(p.writer, "%s,%d,%d,%.2f,%.3f,%s,%s,%s\n",
+			csvQuote(l.Reviewer),
+			l.Requests,
+			l.MaxQueue,
+			l.MeanDailyMax(),
+			l.AverageQueue,
+			csvSummary(l.ResponseTime, l.ResponseTime.Median),
+			csvSummary(l.ResponseTime, l.ResponseTime.Mean),
+			correlation,
+		)
+	}
+
+	fmt.Fprintln(p.writer)
+	fmt.Fprintln(p.writer, "Reviewer,Date,Max_Queue,Average_Queue")
+	for _, l := range loads {
+		for _, d := range l.Daily {
+			fmt.Fprintf(p.writer, "%s,%s,%d,%.3f\n", csvQuote(l.Reviewer), d.Date.Format("2006-01-02"), d.Max, d.Average)
+		}
+	}
+}
+
+// printRubberStamps appends the flagged approvals, followed by the counts per reviewer
+func (p *CSVPrinter) printRubberStamps(report *entity.Report) {
+	if report.RubberStampRule == nil {
+		return
+	}
+
+	fmt.Fprintln(p.writer)
+	fmt.Fprintln(p.writer, "Rubber_Stamp_PR_Number,Reviewer,Changed_Lines,Approved_After_Minutes,Reasons")
+	for _, m := range report.Metrics {
+		for _, s := range m.RubberStamps {
+			fmt.Fprintf(p.writer, "%d,%s,%d,%d,%s\n",
+				m.PullRequest.Number,
+				csvQuote(s.Reviewer),
+				m.PullRequest.ChangedLines(),
+				formatDuration(s.ApprovedAfter),
+				csvQuote(rubberStampReasons(s.Reasons)),
+			)
+		}
+	}
+
+	fmt.Fprintln(p.writer)
+	fmt.Fprintln(p.writer, "Rubber_Stamp_Reviewer,Approval_Count,Flagged_Count,Fast_Count,No_Comments_Count")
+	for _, c := range report.RubberStamps {
+		fmt.Fprintf(p.writer, "%s,%d,%d,%d,%d\n", csvQuote(c.Reviewer), c.Approvals, c.Flagged, c.Fast, c.Silent)
+	}
+}
+
+const csvLatencyHeader = "Outcome,PR_Count,Unreviewed_Count,Median_Time_To_Review_Minutes,Mean_Time_To_Review_Minutes,Median_Time_To_Approve_Minutes,Mean_Time_To_Approve_Minutes"
+
+func csvLatencyCells(g entity.LatencyGroup) string {
+	return fmt.Sprintf("%s,%d,%d,%s,%s,%s,%s",
+		outcomeLabel(g.Outcome),
+		g.Count,
+		g.Unreviewed,
+		csvSummary(g.TimeToReview, g.TimeToReview.Median),
+		csvSummary(g.TimeToReview, g.TimeToReview.Mean),
+		csvSummary(g.TimeToApprove, g.TimeToApprove.Median),
+		csvSummary(g.TimeToApprove, g.TimeToApprove.Mean),
+	)
+}
+
+// csvQuote wraps a free-form value in quotes, replacing quotes inside it
+func csvQuote(s string) string {
+	return "\"" + strings.ReplaceAll(s, "\"", "'") + "\""
+}
+
+// printExclusions appends the filter summary as a separate section after a blank line
+func (p *CSVPrinter) printExclusions(exclusions []entity.Exclusion) {
+	if len(exclusions) == 0 {
+		return
+	}
+
+	fmt.Fprintln(p.writer)
+	fmt.Fprintln(p.writer, "Excluded_Rule,Excluded_Count")
+	for _, e := range exclusions {
+		fmt.Fprintf(p.writer, "%s,%d\n", csvQuote(e.Rule), e.Count)
+	}
+}
+
+func csvDuration(d *time.Duration) string {
+	if d == nil {
+		return ""
+	}
+	return fmt.Sprintf("%d", formatDuration(*d))
+}
+
+// csvProbability formats a probability with four decimals, or an empty string when it is unknown
+func csvProbability(v *float64) string {
+	if v == nil {
+		return ""
+	}
+	return fmt.Sprintf("%.4f", *v)
+}
+
+func csvSummary(s entity.DurationSummary, d time.Duration) string {
+	if s.Count == 0 {
+		return ""
+	}
+	return csvDuration(&d)
+}
+
+// csvRework formats a field of the rework, or an empty string when rework is unknown
+func csvRework(r *entity.Rework, field func(*entity.Rework) int) string {
+	if r == nil {
+		return ""
+	}
+	return fmt.Sprintf("%d", field(r))
+}
+
+func csvBool(b *bool) string {
+	if b == nil {
+		return ""
+	}
+	return fmt.Sprintf("%t", *b)
+}
@@ -0,0 +1,114 @@
+package printer
+
+import (
+	"fmt"
+
+	"github.com/dragoneena12/measure-review-time/domain/entity"
+)
+
+// PrintComparison writes the two groups, then each statistic of each metric side by side,
+// then the confidence intervals of the median differences
+func (p *CSVPrinter) PrintComparison(report *entity.ComparisonReport) error {
+	fmt.Fprintln(p.writer, "Group,PRs_Of,Outcome,PR_Count,Unreviewed_Count,Unreviewed_Percent,Unapproved_Count,Unapproved_Percent")
+	for _, g := range []struct {
+		name  string
+		group entity.ComparisonGroup
+	}{{"baseline", report.Baseline}, {"current", report.Current}} {
+		s := g.group.Summary
+		fmt.Fprintf(p.writer, "%s,%s,%s,%d,%d,%.1f,%d,%.1f\n",
+			g.name,
+			csvQuote(g.group.Label),
+			outcomeLabel(s.Outcome),
+			s.PRs,
+			s.Unreviewed,
+			percent(s.Unreviewed, s.PRs),
+			s.Unapproved,
+			percent(s.Unapproved, s.PRs),
+		)
+	}
+	if report.Overlapping > 0 {
+		fmt.Fprintln(p.writer)
+		fmt.Fprintln(p.writer, "Overlapping_PR_Count")
+		fmt.Fprintf(p.writer, "%d\n", report.Overlapping)
+	}
+
+	if len(report.Metrics) > 0 {
+		fmt.Fprintln(p.writer)
+		fmt.Fprintln(p.writer, "Metric,Statistic,Baseline_Minutes,Current_Minutes,Delta_Minutes,Change_Percent")
+		for _, m := range report.Metrics {
+			for _, stat := range entity.ComparedStatistics {
+				delta, relative := m.Delta(stat)
+				deltaCell := ""
+				if m.Baseline.Count > 0 && m.Current.Count > 0 {
+					deltaCell = csvDuration(&delta)
+				}
+				fmt.Fprintf(p.writer, "%s,%s,%s,%s,%s,%s\n",
+					m.Metric.Key,
+					stat.Key,
+					csvSummary(m.Baseline, stat.Of(m.Baseline)),
+					csvSummary(m.Current, stat.Of(m.Current)),
+					deltaCell,
+					csvPercent(relative),
+				)
+			}
+		}
+
+		fmt.Fprintln(p.writer)
+		fmt.Fprintln(p.writer, "Metric,Baseline_Count,Current_Count,Median_Difference_Minutes,CI_Level,CI_Lower_Minutes,CI_Upper_Minutes")
+		for _, m := range report.Metrics {
+			difference, level, lower, upper := "", "", "", ""
+			if ci := m.MedianDifference; ci != nil {
+				d := m.Current.Median - m.Baseline.Median
+				difference = csvDuration(&d)
+				level = fmt.Sprintf("%.2f", ci.Level)
+				lower = csvDuration(&ci.Lower)
+				upper = csvDuration(&ci.Upper)
+			}
+			fmt.Fprintf(p.writer, "%s,%d,%d,%s,%s,%s,%s\n",
+				m.Metric.Key,
+				m.Baseline.Count,
+				m.Current.Count,
+				difference,
+				level,
+				lower,
+				upper,
+			)
+		}
+
+		fmt.Fprintln(p.writer)
+		fmt.Fprintln(p.writer, "Metric,Mann_Whitney_U,Z,P_Value,Holm_P_Value,Effect_Size,Effect_Magnitude")
+		for _, m := range report.Metrics {
+			if m.Test == nil {
+				fmt.Fprintf(p.writer, "%s,,,,,,\n", m.Metric.Key)
+				continue
+			}
+			fmt.Fprintf(p.writer, "%s,%.1f,%.4f,%.6f,%.6f,%.4f,%s\n",
+				m.Metric.Key,
+				m.Test.U,
+				m.Test.Z,
+				m.Test.PValue,
+				m.Test.AdjustedPValue,
+				m.Test.EffectSize,
+				effectMagnitude(m.Test.EffectSize),
+			)
+		}
+	}
+
+	p.printExclusions(report.Exclusions)
+	if exclusions := groupExclusions(report); len(exclusions) > 0 {
+		fmt.Fprintln(p.writer)
+		fmt.Fprintln(p.writer, "Excluded_Rule,Baseline_Count,Current_Count")
+		for _, e := range exclusions {
+			fmt.Fprintf(p.writer, "%s,%d,%d\n", csvQuote(e.Rule), e.Baseline, e.Current)
+		}
+	}
+	return nil
+}
+
+// csvPercent formats a percentage with one decimal, or an empty string when there is none
+func csvPercent(v *float64) string {
+	if v == nil {
+		return ""
+	}
+	return fmt.Sprintf("%.1f", *v)
+}
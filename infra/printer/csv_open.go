@@ -0,0 +1,61 @@
+package printer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dragoneena12/measure-review-time/domain/entity"
+)
+
+func (p *CSVPrinter) PrintOpenPRs(report *entity.AgingReport) error {
+	fmt.Fprintln(p.writer, strings.Join([]string{
+		"PR_Number", "Title", "Author", "Created_At", "Size", "Stage", "Waiting_Minutes",
+		"Approvals", "Required_Approvals", "Requested_Reviewers",
+	}, ","))
+
+	for _, open := range report.PullRequests {
+		pr := open.Metrics.PullRequest
+
+		waiting := ""
+		if open.Stage != entity.StageApproved {
+			waiting = csvDuration(&open.Waiting)
+		}
+
+		fmt.Fprintln(p.writer, strings.Join([]string{
+			fmt.Sprintf("%d", pr.Number),
+			csvQuote(strings.ReplaceAll(pr.Title, ",", ";")),
+			pr.Author,
+			pr.CreatedAt.Format("2006-01-02 15:04:05"),
+			string(open.Metrics.Size),
+			string(open.Stage),
+			waiting,
+			fmt.Sprintf("%d", len(pr.Approvals)),
+			fmt.Sprintf("%d", max(pr.RequiredApprovals, 1)),
+			csvQuote(strings.Join(pr.RequestedReviewers, ";")),
+		}, ","))
+	}
+
+	debt := report.Debt
+	fmt.Fprintln(p.writer)
+	fmt.Fprintln(p.writer, "Open_PRs,Awaiting_Review,Awaiting_Approval,Approved,Total_Wait_Minutes,Median_Wait_Minutes,Longest_Wait_Minutes")
+	fmt.Fprintf(p.writer, "%d,%d,%d,%d,%s,%s,%s\n",
+		debt.OpenPRs,
+		debt.AwaitingReview,
+		debt.AwaitingApproval,
+		debt.Approved,
+		csvSummary(debt.Wait, debt.TotalWait),
+		csvSummary(debt.Wait, debt.Wait.Median),
+		csvSummary(debt.Wait, debt.LongestWait),
+	)
+
+	if len(debt.ByReviewer) > 0 {
+		fmt.Fprintln(p.writer)
+		fmt.Fprintln(p.writer, "Reviewer,Requested,Longest_Wait_Minutes")
+		for _, r := range debt.ByReviewer {
+			fmt.Fprintf(p.writer, "%s,%d,%s\n", csvQuote(r.Reviewer), r.Requested, csvDuration(&r.LongestWait))
+		}
+	}
+
+	p.printExclusions(report.Exclusions)
+	return nil
+}
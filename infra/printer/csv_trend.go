@@ -0,0 +1,51 @@
+package printer
+
+import (
+	"fmt"
+
+	"github.com/dragoneena12/measure-review-time/domain/entity"
+)
+
+// PrintTrend writes one row per bucket, then a section in long format with one row per
+// bucket and metric, so the series can be pivoted or charted directly
+func (p *CSVPrinter) PrintTrend(report *entity.TrendReport) error {
+	fmt.Fprintln(p.writer, "Bucket_Start,Bucket_End,Outcome,PR_Count,Unreviewed_Count,Unreviewed_Percent,Unapproved_Count,Unapproved_Percent")
+	for _, b := range report.Buckets {
+		s := b.Summary
+		fmt.Fprintf(p.writer, "%s,%s,%s,%d,%d,%.1f,%d,%.1f\n",
+			b.Start.Format("2006-01-02"),
+			b.End.Format("2006-01-02"),
+			outcomeLabel(s.Outcome),
+			s.PRs,
+			s.Unreviewed,
+			percent(s.Unreviewed, s.PRs),
+			s.Unapproved,
+			percent(s.Unapproved, s.PRs),
+		)
+	}
+
+	if metrics := report.Metrics(); len(metrics) > 0 {
+		fmt.Fprintln(p.writer)
+		fmt.Fprintln(p.writer, "Bucket_Start,Metric,Count,Mean_Minutes,Median_Minutes,P75_Minutes,P90_Minutes,P95_Minutes,Min_Minutes,Max_Minutes")
+		for _, metric := range metrics {
+			for _, b := range report.Buckets {
+				s := b.Summary.Metric(metric.Key)
+				fmt.Fprintf(p.writer, "%s,%s,%d,%s,%s,%s,%s,%s,%s,%s\n",
+					b.Start.Format("2006-01-02"),
+					metric.Key,
+					s.Count,
+					csvSummary(s, s.Mean),
+					csvSummary(s, s.Median),
+					csvSummary(s, s.P75),
+					csvSummary(s, s.P90),
+					csvSummary(s, s.P95),
+					csvSummary(s, s.Min),
+					csvSummary(s, s.Max),
+				)
+			}
+		}
+	}
+
+	p.printExclusions(report.Exclusions)
+	return nil
+}
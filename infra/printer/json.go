@@ -21,43 +21,411 @@ func NewJSONPrinter() repository.Printer {
 	}
 }
 
-func (p *JSONPrinter) Print(owner, repo string, metrics []*entity.ReviewMetrics) error {
-	if len(metrics) == 0 {
-		fmt.Fprintln(p.writer, "[]")
-		return nil
-	}
-
+func (p *JSONPrinter) Print(report *entity.Report) error {
 	output := map[string]any{
-		"repository": fmt.Sprintf("%s/%s", owner, repo),
+		"repository":    fmt.Sprintf("%s/%s", report.Owner, report.Repo),
 		"pull_requests": []map[string]any{},
 	}
 
+	// Without PRs there is nothing to summarize, but the exclusions still tell why
+	if len(report.Metrics) == 0 {
+		if len(report.Exclusions) > 0 {
+			output["exclusions"] = exclusionsJSON(report.Exclusions)
+		}
+		return p.write(output)
+	}
+
 	pullRequests := []map[string]any{}
-	for _, metric := range metrics {
+	for _, metric := range report.Metrics {
 		pr := metric.PullRequest
 		prMap := map[string]any{
-			"number":     pr.Number,
-			"title":      pr.Title,
-			"author":     pr.Author,
-			"created_at": pr.CreatedAt.Format(time.RFC3339),
+			"number":        pr.Number,
+			"title":         pr.Title,
+			"author":        pr.Author,
+			"outcome":       metric.Outcome,
+			"created_at":    pr.CreatedAt.Format(time.RFC3339),
+			"size":          metric.Size,
+			"additions":     pr.Additions,
+			"deletions":     pr.Deletions,
+			"changed_files": pr.ChangedFiles,
+			"commits":       pr.Commits,
+
+			"dismissed_approvals": pr.DismissedApprovals,
+			"stale_approvals":     pr.StaleApprovals,
+			"required_approvals":  max(pr.RequiredApprovals, 1),
+
+			"review_comments":               metric.ReviewDepth.Comments,
+			"review_commenters":             metric.ReviewDepth.Commenters,
+			"review_comments_per_100_lines": metric.ReviewDepth.CommentsPer100Lines,
 		}
 
-		if metric.TimeToReview != nil {
-			prMap["time_to_review_minutes"] = formatDuration(*metric.TimeToReview)
+		setDuration(prMap, "coding_time_minutes", metric.CodingTime)
+		setDuration(prMap, "time_to_request_minutes", metric.TimeToRequest)
+		setDuration(prMap, "time_to_first_response_minutes", metric.TimeToFirstResponse)
+		setDuration(prMap, "time_to_review_minutes", metric.TimeToReview)
+		setDuration(prMap, "time_to_automated_review_minutes", metric.TimeToAutomatedReview)
+		setDuration(prMap, "review_to_approve_minutes", metric.ReviewToApprove)
+		setDuration(prMap, "time_to_approve_minutes", metric.TimeToApprove)
+		setDuration(prMap, "time_to_final_approve_minutes", metric.TimeToFinalApprove)
+		setDuration(prMap, "time_to_required_approvals_minutes", metric.TimeToRequiredApprovals)
+		setDuration(prMap, "approve_to_merge_minutes", metric.ApproveToMerge)
+		setDuration(prMap, "lifetime_minutes", metric.TotalDuration)
+		setDuration(prMap, "merge_to_deploy_minutes", metric.MergeToDeploy)
+		setDuration(prMap, "commit_to_deploy_minutes", metric.CommitToDeploy)
+		if pr.DeployedAt != nil {
+			prMap["deployed_at"] = pr.DeployedAt.Format(time.RFC3339)
 		}
-		if metric.TimeToApprove != nil {
-			prMap["time_to_approve_minutes"] = formatDuration(*metric.TimeToApprove)
+
+		if metric.Rework != nil {
+			prMap["rework"] = map[string]any{
+				"commits":   metric.Rework.Commits,
+				"additions": metric.Rework.Additions,
+				"deletions": metric.Rework.Deletions,
+			}
+		}
+		setDuration(prMap, "review_to_last_push_minutes", metric.ReviewToLastPush)
+
+		if b := metric.BallInCourt; b != nil {
+			prMap["ball_in_court"] = map[string]any{
+				"reviewer_wait_minutes":       formatDuration(b.ReviewerWait),
+				"author_wait_minutes":         formatDuration(b.AuthorWait),
+				"idle_after_approval_minutes": formatDuration(b.IdleAfterApproval),
+			}
+		}
+
+		if report.Outliers != nil {
+			prMap["outliers"] = nonNil(metric.Outliers)
+		}
+
+		if metric.FeedbackResponses != nil {
+			feedback := []map[string]any{}
+			for _, f := range metric.FeedbackResponses {
+				item := map[string]any{
+					"reviewer":    f.Reviewer,
+					"state":       f.State,
+					"reviewed_at": f.ReviewedAt.Format(time.RFC3339),
+				}
+				setDuration(item, "response_minutes", f.ResponseTime())
+				feedback = append(feedback, item)
+			}
+			prMap["feedback_responses"] = feedback
+		}
+
+		if report.RubberStampRule != nil {
+			prMap["rubber_stamps"] = rubberStampsJSON(metric.RubberStamps)
+		}
+
+		approvals := []map[string]any{}
+		for i, a := range pr.Approvals {
+			approvals = append(approvals, map[string]any{
+				"order":       i + 1,
+				"reviewer":    a.Reviewer,
+				"approved_at": a.At.Format(time.RFC3339),
+			})
+		}
+		prMap["approvals"] = approvals
+
+		if metric.Areas != nil {
+			prMap["areas"] = metric.Areas
+		}
+		if metric.CodeOwnerApproved != nil {
+			prMap["code_owner_approved"] = *metric.CodeOwnerApproved
 		}
 
 		pullRequests = append(pullRequests, prMap)
 	}
 	output["pull_requests"] = pullRequests
 
+	if report.Summary != nil {
+		output["summary"] = reportSummaryJSON(report.Summary)
+	}
+
+	if detection := report.Outliers; detection != nil {
+		fences := map[string]any{}
+		for _, fence := range detection.Fences {
+			prs := []map[string]any{}
+			for _, m := range outlierMetrics(report, fence) {
+				item := map[string]any{"number": m.PullRequest.Number}
+				setDuration(item, "value_minutes", fence.Metric.Of(m))
+				prs = append(prs, item)
+			}
+			fences[fence.Metric.Key] = map[string]any{
+				"lower_minutes": formatDuration(fence.Lower),
+				"upper_minutes": formatDuration(fence.Upper),
+				"pull_requests": prs,
+			}
+		}
+		output["outliers"] = map[string]any{
+			"method":                detection.Method,
+			"excluded_from_summary": detection.Excluded,
+			"metrics":               fences,
+		}
+	}
+
+	if len(report.Survival) > 0 {
+		survival := map[string]any{}
+		for _, s := range report.Survival {
+			c := s.Curve
+			stillWaiting := map[string]any{}
+			for _, h := range entity.SurvivalHorizons {
+				if v := c.StillWaiting(h.After); v != nil {
+					stillWaiting[h.Label] = *v
+				}
+			}
+			item := map[string]any{
+				"pr_count":       c.Observations,
+				"event_count":    c.Events,
+				"censored_count": c.Observations - c.Events,
+				"still_waiting":  stillWaiting,
+			}
+			setDuration(item, "median_minutes", c.Median())
+			survival[s.Metric.Key] = item
+		}
+		output["survival"] = survival
+	}
+
+	if len(report.Outcomes) > 0 {
+		outcomes := []map[string]any{}
+		for _, g := range report.Outcomes {
+			outcomes = append(outcomes, latencyGroupJSON("outcome", g))
+		}
+		output["outcomes"] = outcomes
+	}
+
+	if len(report.SizeBuckets) > 0 {
+		output["size_buckets"] = latencyGroupsJSON("size", report.SizeBuckets)
+	}
+
+	if len(report.Areas) > 0 {
+		areas := []map[string]any{}
+		for _, a := range report.Areas {
+			area := latencyGroupJSON("area", a.LatencyGroup)
+			area["approved_count"] = a.Approved
+			area["code_owner_approved_count"] = a.CodeOwnerApproved
+			areas = append(areas, area)
+		}
+		output["areas"] = areas
+	}
+
+	if len(report.Rework) > 0 {
+		rework := []map[string]any{}
+		for _, g := range report.Rework {
+			rework = append(rework, map[string]any{
+				"team":                g.Team,
+				"reviewed_count":      g.PRs,
+				"reworked_count":      g.Reworked,
+				"commits":             g.Commits,
+				"lines":               g.Lines,
+				"review_to_last_push": summaryJSON(g.ReviewToLastPush),
+			})
+		}
+		output["rework"] = rework
+	}
+
+	if len(report.TeamWaits) > 0 {
+		teams := []map[string]any{}
+		for _, g := range report.TeamWaits {
+			teams = append(teams, map[string]any{
+				"team":                              g.Team,
+				"count":                             g.PRs,
+				"reviewer_wait":                     summaryJSON(g.ReviewerWait),
+				"author_wait":                       summaryJSON(g.AuthorWait),
+				"idle_after_approval":               summaryJSON(g.IdleAfterApproval),
+				"total_reviewer_wait_minutes":       formatDuration(g.Total.ReviewerWait),
+				"total_author_wait_minutes":         formatDuration(g.Total.AuthorWait),
+				"total_idle_after_approval_minutes": formatDuration(g.Total.IdleAfterApproval),
+			})
+		}
+		output["ball_in_court"] = teams
+	}
+
+	if report.RepoAuthorResponse != nil {
+		authors := []map[string]any{}
+		for _, a := range report.AuthorResponses {
+			authors = append(authors, authorResponseJSON(a))
+		}
+		output["author_response"] = map[string]any{
+			"repository": authorResponseJSON(*report.RepoAuthorResponse),
+			"by_author":  authors,
+		}
+	}
+
+	if report.RubberStampRule != nil {
+		rule := report.RubberStampRule
+		flagged := []int{}
+		for _, m := range report.Metrics {
+			if len(m.RubberStamps) > 0 {
+				flagged = append(flagged, m.PullRequest.Number)
+			}
+		}
+		byReviewer := []map[string]any{}
+		for _, c := range report.RubberStamps {
+			byReviewer = append(byReviewer, map[string]any{
+				"reviewer":      c.Reviewer,
+				"approvals":     c.Approvals,
+				"flagged":       c.Flagged,
+				"fast_approval": c.Fast,
+				"no_comments":   c.Silent,
+			})
+		}
+		output["rubber_stamps"] = map[string]any{
+			"rule": map[string]any{
+				"fast_approval_minutes":     formatDuration(rule.FastApproval),
+				"fast_approval_min_lines":   rule.FastApprovalMinLines,
+				"silent_approval_min_lines": rule.SilentApprovalMinLines,
+			},
+			"flagged_pull_requests": flagged,
+			"by_reviewer":           byReviewer,
+		}
+	}
+
+	if len(report.ReviewerLoad) > 0 {
+		output["reviewer_load"] = reviewerLoadJSON(report.ReviewerLoad)
+	}
+
+	if len(report.Exclusions) > 0 {
+		output["exclusions"] = exclusionsJSON(report.Exclusions)
+	}
+
+	return p.write(output)
+}
+
+func (p *JSONPrinter) write(output map[string]any) error {
 	jsonBytes, err := json.MarshalIndent(output, "", "  ")
 	if err != nil {
 		return fmt.Errorf("error encoding JSON: %w", err)
 	}
-	
+
 	fmt.Fprintln(p.writer, string(jsonBytes))
 	return nil
-}
\ No newline at end of file
+}
+
+// setDuration adds the duration in minutes under key, omitting nil values
+func setDuration(m map[string]any, key string, d *time.Duration) {
+	if d != nil {
+		m[key] = formatDuration(*d)
+	}
+}
+
+func latencyGroupsJSON(keyName string, groups []entity.LatencyGroup) []map[string]any {
+	result := []map[string]any{}
+	for _, g := range groups {
+		result = append(result, latencyGroupJSON(keyName, g))
+	}
+	return result
+}
+
+func latencyGroupJSON(keyName string, g entity.LatencyGroup) map[string]any {
+	result := map[string]any{
+		keyName:            g.Key,
+		"count":            g.Count,
+		"unreviewed_count": g.Unreviewed,
+		"time_to_review":   summaryJSON(g.TimeToReview),
+		"time_to_approve":  summaryJSON(g.TimeToApprove),
+	}
+	if g.Outcome != "" {
+		result["outcome"] = g.Outcome
+	}
+	return result
+}
+
+func summaryJSON(s entity.DurationSummary) map[string]any {
+	result := map[string]any{
+		"count": s.Count,
+	}
+	if s.Count > 0 {
+		result["mean_minutes"] = formatDuration(s.Mean)
+		result["median_minutes"] = formatDuration(s.Median)
+		result["p75_minutes"] = formatDuration(s.P75)
+		result["p90_minutes"] = formatDuration(s.P90)
+		result["p95_minutes"] = formatDuration(s.P95)
+		result["min_minutes"] = formatDuration(s.Min)
+		result["max_minutes"] = formatDuration(s.Max)
+	}
+	return result
+}
+
+func reportSummaryJSON(summary *entity.Summary) map[string]any {
+	metrics := map[string]any{}
+	for _, m := range summary.Metrics {
+		metric := summaryJSON(m.DurationSummary)
+		if m.Outliers > 0 {
+			metric["excluded_outliers"] = m.Outliers
+		}
+		metrics[m.Metric.Key] = metric
+	}
+	return map[string]any{
+		"outcome":            outcomeLabel(summary.Outcome),
+		"pr_count":           summary.PRs,
+		"unreviewed_count":   summary.Unreviewed,
+		"unreviewed_percent": percent(summary.Unreviewed, summary.PRs),
+		"unapproved_count":   summary.Unapproved,
+		"unapproved_percent": percent(summary.Unapproved, summary.PRs),
+		"metrics":            metrics,
+	}
+}
+
+func authorResponseJSON(a entity.AuthorResponsiveness) map[string]any {
+	result := map[string]any{
+		"pr_count":         a.PRs,
+		"feedback_count":   a.Feedback,
+		"unanswered_count": a.Unanswered,
+		"response_time":    summaryJSON(a.ResponseTime),
+	}
+	if a.Author != "" {
+		result["author"] = a.Author
+	}
+	return result
+}
+
+func rubberStampsJSON(stamps []entity.RubberStamp) []map[string]any {
+	result := []map[string]any{}
+	for _, s := range stamps {
+		result = append(result, map[string]any{
+			"reviewer":               s.Reviewer,
+			"approved_after_minutes": formatDuration(s.ApprovedAfter),
+			"reasons":                s.Reasons,
+		})
+	}
+	return result
+}
+
+func reviewerLoadJSON(loads []entity.ReviewerLoad) []map[string]any {
+	result := []map[string]any{}
+	for _, l := range loads {
+		daily := []map[string]any{}
+		for _, d := range l.Daily {
+			daily = append(daily, map[string]any{
+				"date":          d.Date.Format("2006-01-02"),
+				"max_queue":     d.Max,
+				"average_queue": d.Average,
+			})
+		}
+
+		load := map[string]any{
+			"reviewer":             l.Reviewer,
+			"requests":             l.Requests,
+			"max_queue":            l.MaxQueue,
+			"mean_daily_max_queue": l.MeanDailyMax(),
+			"average_queue":        l.AverageQueue,
+			"response_time":        summaryJSON(l.ResponseTime),
+			"daily":                daily,
+		}
+		if l.LoadCorrelation != nil {
+			load["load_response_correlation"] = *l.LoadCorrelation
+		}
+		result = append(result, load)
+	}
+	return result
+}
+
+func exclusionsJSON(exclusions []entity.Exclusion) []map[string]any {
+	result := []map[string]any{}
+	for _, e := range exclusions {
+		result = append(result, map[string]any{
+			"rule":  e.Rule,
+			"count": e.Count,
+		})
+	}
+	return result
+}
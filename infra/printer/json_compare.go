@@ -0,0 +1,79 @@
+package printer
+
+import (
+	"fmt"
+
+	"github.com/dragoneena12/measure-review-time/domain/entity"
+)
+
+func (p *JSONPrinter) PrintComparison(report *entity.ComparisonReport) error {
+	metrics := map[string]any{}
+	for _, m := range report.Metrics {
+		deltas := map[string]any{}
+		if m.Baseline.Count > 0 && m.Current.Count > 0 {
+			for _, stat := range entity.ComparedStatistics {
+				delta, relative := m.Delta(stat)
+				item := map[string]any{
+					"delta_minutes": formatDuration(delta),
+				}
+				if relative != nil {
+					item["change_percent"] = *relative
+				}
+				deltas[stat.Key] = item
+			}
+		}
+
+		metric := map[string]any{
+			"baseline": summaryJSON(m.Baseline),
+			"current":  summaryJSON(m.Current),
+			"deltas":   deltas,
+		}
+		if ci := m.MedianDifference; ci != nil {
+			metric["median_difference"] = map[string]any{
+				"difference_minutes": formatDuration(m.Current.Median - m.Baseline.Median),
+				"level":              ci.Level,
+				"lower_minutes":      formatDuration(ci.Lower),
+				"upper_minutes":      formatDuration(ci.Upper),
+				"excludes_zero":      ci.ExcludesZero(),
+			}
+		}
+		if t := m.Test; t != nil {
+			metric["mann_whitney"] = map[string]any{
+				"u":                t.U,
+				"z":                t.Z,
+				"p_value":          t.PValue,
+				"holm_p_value":     t.AdjustedPValue,
+				"effect_size":      t.EffectSize,
+				"effect_magnitude": effectMagnitude(t.EffectSize),
+				"significant":      m.Significant(significanceLevel),
+			}
+		}
+		metrics[m.Metric.Key] = metric
+	}
+
+	output := map[string]any{
+		"repository": fmt.Sprintf("%s/%s", report.Owner, report.Repo),
+		"baseline":   comparisonGroupJSON(report.Baseline),
+		"current":    comparisonGroupJSON(report.Current),
+		"metrics":    metrics,
+	}
+	if report.Overlapping > 0 {
+		output["overlapping_pull_requests"] = report.Overlapping
+	}
+	if len(report.Exclusions) > 0 {
+		output["exclusions"] = exclusionsJSON(report.Exclusions)
+	}
+
+	return p.write(output)
+}
+
+func comparisonGroupJSON(g entity.ComparisonGroup) map[string]any {
+	group := map[string]any{
+		"label":   g.Label,
+		"summary": reportSummaryJSON(&g.Summary),
+	}
+	if len(g.Exclusions) > 0 {
+		group["exclusions"] = exclusionsJSON(g.Exclusions)
+	}
+	return group
+}
@@ -0,0 +1,73 @@
+package printer
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dragoneena12/measure-review-time/domain/entity"
+)
+
+func (p *JSONPrinter) PrintOpenPRs(report *entity.AgingReport) error {
+	pullRequests := []map[string]any{}
+	for _, open := range report.PullRequests {
+		pr := open.Metrics.PullRequest
+		prMap := map[string]any{
+			"number":              pr.Number,
+			"title":               pr.Title,
+			"author":              pr.Author,
+			"created_at":          pr.CreatedAt.Format(time.RFC3339),
+			"size":                open.Metrics.Size,
+			"stage":               open.Stage,
+			"approvals":           len(pr.Approvals),
+			"required_approvals":  max(pr.RequiredApprovals, 1),
+			"requested_reviewers": nonNil(pr.RequestedReviewers),
+		}
+		if open.Stage != entity.StageApproved {
+			prMap["waiting_minutes"] = formatDuration(open.Waiting)
+		}
+		pullRequests = append(pullRequests, prMap)
+	}
+
+	debt := report.Debt
+	debtMap := map[string]any{
+		"open_prs":          debt.OpenPRs,
+		"awaiting_review":   debt.AwaitingReview,
+		"awaiting_approval": debt.AwaitingApproval,
+		"approved":          debt.Approved,
+	}
+	if debt.Wait.Count > 0 {
+		debtMap["total_wait_minutes"] = formatDuration(debt.TotalWait)
+		debtMap["median_wait_minutes"] = formatDuration(debt.Wait.Median)
+		debtMap["longest_wait_minutes"] = formatDuration(debt.LongestWait)
+	}
+
+	byReviewer := []map[string]any{}
+	for _, r := range debt.ByReviewer {
+		byReviewer = append(byReviewer, map[string]any{
+			"reviewer":             r.Reviewer,
+			"requested":            r.Requested,
+			"longest_wait_minutes": formatDuration(r.LongestWait),
+		})
+	}
+	debtMap["by_reviewer"] = byReviewer
+
+	output := map[string]any{
+		"repository":    fmt.Sprintf("%s/%s", report.Owner, report.Repo),
+		"generated_at":  report.GeneratedAt.Format(time.RFC3339),
+		"pull_requests": pullRequests,
+		"review_debt":   debtMap,
+	}
+	if len(report.Exclusions) > 0 {
+		output["exclusions"] = exclusionsJSON(report.Exclusions)
+	}
+
+	return p.write(output)
+}
+
+// nonNil makes nil slices encode as [] rather than null
+func nonNil(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
@@ -0,0 +1,31 @@
+package printer
+
+import (
+	"fmt"
+
+	"github.com/dragoneena12/measure-review-time/domain/entity"
+)
+
+func (p *JSONPrinter) PrintTrend(report *entity.TrendReport) error {
+	buckets := []map[string]any{}
+	for _, b := range report.Buckets {
+		buckets = append(buckets, map[string]any{
+			"start":   b.Start.Format("2006-01-02"),
+			"end":     b.End.Format("2006-01-02"),
+			"summary": reportSummaryJSON(&b.Summary),
+		})
+	}
+
+	output := map[string]any{
+		"repository":    fmt.Sprintf("%s/%s", report.Owner, report.Repo),
+		"interval":      report.Interval,
+		"date":          report.By,
+		"undated_count": report.Undated,
+		"buckets":       buckets,
+	}
+	if len(report.Exclusions) > 0 {
+		output["exclusions"] = exclusionsJSON(report.Exclusions)
+	}
+
+	return p.write(output)
+}
@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strings"
 	"text/tabwriter"
+	"time"
 
 	"github.com/dragoneena12/measure-review-time/domain/entity"
 	"github.com/dragoneena12/measure-review-time/domain/repository"
@@ -20,51 +22,556 @@ func NewTablePrinter() repository.Printer {
 	}
 }
 
-func (p *TablePrinter) Print(owner, repo string, metrics []*entity.ReviewMetrics) error {
-	if len(metrics) == 0 {
+func (p *TablePrinter) Print(report *entity.Report) error {
+	if len(report.Metrics) == 0 {
 		fmt.Fprintln(p.writer, "No pull requests found")
+		p.printExclusions(report.Exclusions)
 		return nil
 	}
 
-	fmt.Fprintf(p.writer, "\n=== PR Review Time Report for %s/%s ===\n\n", owner, repo)
+	fmt.Fprintf(p.writer, "\n=== PR Review Time Report for %s/%s ===\n\n", report.Owner, report.Repo)
 
 	// Create a new tabwriter
 	w := tabwriter.NewWriter(p.writer, 0, 0, 2, ' ', 0)
-	defer w.Flush()
 
-	// Print table header
-	fmt.Fprintln(w, "PR #\tAuthor\tCreated\tTime to Review\tTime to Approve\tTitle")
-	fmt.Fprintln(w, "----\t------\t-------\t--------------\t---------------\t-----")
+	columns := prColumns(report)
+	header := make([]string, len(columns))
+	for i, c := range columns {
+		header[i] = c.header
+	}
+	printTableRow(w, header, true)
+
+	for _, metric := range report.Metrics {
+		row := make([]string, len(columns))
+		for i, c := range columns {
+			row[i] = c.cell(metric)
+		}
+		printTableRow(w, row, false)
+	}
+	w.Flush()
+
+	fmt.Fprintln(p.writer)
+	p.printSummary(report.Summary, report.Outliers)
+	p.printOutliers(report)
+	p.printSurvival(report.Survival)
+	p.printOutcomes(report.Outcomes)
+	p.printLatencyGroups("Latency by PR Size", "Size", report.SizeBuckets)
+	p.printAreas(report.Areas)
+	p.printRework(report.Rework)
+	p.printTeamWaits(report.TeamWaits)
+	p.printAuthorResponses(report)
+	p.printReviewerLoad(report.ReviewerLoad)
+	p.printRubberStamps(report)
+	p.printExclusions(report.Exclusions)
+	return nil
+}
+
+// prColumn is a column of the per-PR table
+type prColumn struct {
+	header string
+	cell   func(*entity.ReviewMetrics) string
+}
+
+// prColumns returns the columns of the per-PR table, leaving out the metrics not measured
+func prColumns(report *entity.Report) []prColumn {
+	measured := report.Measured
+	var columns []prColumn
+	add := func(enabled bool, header string, cell func(*entity.ReviewMetrics) string) {
+		if enabled {
+			columns = append(columns, prColumn{header: header, cell: cell})
+		}
+	}
+	duration := func(enabled bool, header string, of func(*entity.ReviewMetrics) *time.Duration) {
+		add(enabled, header, func(m *entity.ReviewMetrics) string { return tableDuration(of(m)) })
+	}
 
-	// Print each PR
-	for _, metric := range metrics {
-		pr := metric.PullRequest
-		
-		title := pr.Title
+	add(true, "PR #", func(m *entity.ReviewMetrics) string { return fmt.Sprintf("%d", m.PullRequest.Number) })
+	add(true, "Author", func(m *entity.ReviewMetrics) string { return truncateString(m.PullRequest.Author, 20) })
+	add(true, "Outcome", func(m *entity.ReviewMetrics) string { return string(m.Outcome) })
+	add(true, "Created", func(m *entity.ReviewMetrics) string { return m.PullRequest.CreatedAt.Format("2006-01-02 15:04") })
+	add(true, "Size", func(m *entity.ReviewMetrics) string {
+		return fmt.Sprintf("%s (+%d/-%d)", m.Size, m.PullRequest.Additions, m.PullRequest.Deletions)
+	})
+	add(true, "Review Comments", func(m *entity.ReviewMetrics) string { return tableReviewDepth(m.ReviewDepth) })
+	duration(measured.CodingTime, "Coding Time", func(m *entity.ReviewMetrics) *time.Duration { return m.CodingTime })
+	duration(true, "Time to Request", func(m *entity.ReviewMetrics) *time.Duration { return m.TimeToRequest })
+	duration(true, "First Response", func(m *entity.ReviewMetrics) *time.Duration { return m.TimeToFirstResponse })
+	duration(true, "Time to Review", func(m *entity.ReviewMetrics) *time.Duration { return m.TimeToReview })
+	duration(true, "Automated Review", func(m *entity.ReviewMetrics) *time.Duration { return m.TimeToAutomatedReview })
+	duration(true, "Review to Approve", func(m *entity.ReviewMetrics) *time.Duration { return m.ReviewToApprove })
+	duration(true, "Time to Approve", func(m *entity.ReviewMetrics) *time.Duration { return m.TimeToApprove })
+	duration(true, "Final Approve", func(m *entity.ReviewMetrics) *time.Duration { return m.TimeToFinalApprove })
+	add(true, "Required Approvals", func(m *entity.ReviewMetrics) string {
+		pr := m.PullRequest
+		return fmt.Sprintf("%s (%d/%d)", tableDuration(m.TimeToRequiredApprovals), len(pr.Approvals), max(pr.RequiredApprovals, 1))
+	})
+	duration(true, "Approve to Merge", func(m *entity.ReviewMetrics) *time.Duration { return m.ApproveToMerge })
+	duration(measured.Deployments, "Merge to Deploy", func(m *entity.ReviewMetrics) *time.Duration { return m.MergeToDeploy })
+	duration(true, "Lifetime", func(m *entity.ReviewMetrics) *time.Duration { return m.TotalDuration })
+	duration(measured.Deployments, "Commit to Deploy", func(m *entity.ReviewMetrics) *time.Duration { return m.CommitToDeploy })
+	add(measured.Rework, "Rework", func(m *entity.ReviewMetrics) string { return tableRework(m.Rework) })
+	duration(measured.Rework, "Review to Last Push", func(m *entity.ReviewMetrics) *time.Duration { return m.ReviewToLastPush })
+	duration(measured.BallInCourt, "Reviewer Wait", func(m *entity.ReviewMetrics) *time.Duration {
+		reviewer, _, _ := ballInCourtDurations(m.BallInCourt)
+		return reviewer
+	})
+	duration(measured.BallInCourt, "Author Wait", func(m *entity.ReviewMetrics) *time.Duration {
+		_, author, _ := ballInCourtDurations(m.BallInCourt)
+		return author
+	})
+	duration(measured.BallInCourt, "Idle After Approval", func(m *entity.ReviewMetrics) *time.Duration {
+		_, _, idle := ballInCourtDurations(m.BallInCourt)
+		return idle
+	})
+	add(measured.AuthorResponse, "Author Response", func(m *entity.ReviewMetrics) string { return tableFeedback(m.FeedbackResponses) })
+	add(report.Outliers != nil, "Outliers", func(m *entity.ReviewMetrics) string { return tableOutliers(report.Outliers, m) })
+	add(true, "Title", func(m *entity.ReviewMetrics) string {
+		title := m.PullRequest.Title
 		if len(title) > 60 {
 			title = title[:57] + "..."
 		}
+		return title
+	})
+	return columns
+}
+
+func (p *TablePrinter) printSummary(summary *entity.Summary, outliers *entity.OutlierDetection) {
+	if summary == nil {
+		return
+	}
 
-		timeToReview := "N/A"
-		if metric.TimeToReview != nil {
-			timeToReview = fmt.Sprintf("%d min", formatDuration(*metric.TimeToReview))
+	fmt.Fprintf(p.writer, "=== Summary (%s PRs) ===\n", outcomeLabel(summary.Outcome))
+	fmt.Fprintln(p.writer)
+
+	excluded := outliers != nil && outliers.Excluded
+	w := tabwriter.NewWriter(p.writer, 0, 0, 2, ' ', 0)
+	header := []string{"Metric", "PRs", "Mean", "Median", "P75", "P90", "P95", "Min", "Max"}
+	if excluded {
+		header = append(header, "Excluded Outliers")
+	}
+	printTableRow(w, header, true)
+	for _, m := range summary.Metrics {
+		s := m.DurationSummary
+		row := []string{
+			m.Metric.Name,
+			fmt.Sprintf("%d", s.Count),
+			tableSummary(s, s.Mean),
+			tableSummary(s, s.Median),
+			tableSummary(s, s.P75),
+			tableSummary(s, s.P90),
+			tableSummary(s, s.P95),
+			tableSummary(s, s.Min),
+			tableSummary(s, s.Max),
+		}
+		if excluded {
+			row = append(row, fmt.Sprintf("%d", m.Outliers))
 		}
+		printTableRow(w, row, false)
+	}
+	w.Flush()
+
+	fmt.Fprintln(p.writer)
+	fmt.Fprintf(p.writer, "No review: %d of %d PRs (%.1f%%)\n", summary.Unreviewed, summary.PRs, percent(summary.Unreviewed, summary.PRs))
+	fmt.Fprintf(p.writer, "No approval: %d of %d PRs (%.1f%%)\n", summary.Unapproved, summary.PRs, percent(summary.Unapproved, summary.PRs))
+	fmt.Fprintln(p.writer)
+}
 
-		timeToApprove := "N/A"
-		if metric.TimeToApprove != nil {
-			timeToApprove = fmt.Sprintf("%d min", formatDuration(*metric.TimeToApprove))
+func (p *TablePrinter) printOutliers(report *entity.Report) {
+	detection := report.Outliers
+	if detection == nil {
+		return
+	}
+
+	fmt.Fprintf(p.writer, "=== Outliers (%s) ===\n\n", outlierMethodName(detection.Method))
+	if detection.Excluded {
+		fmt.Fprintln(p.writer, "Outliers are left out of the summary statistics.")
+		fmt.Fprintln(p.writer)
+	}
+
+	w := tabwriter.NewWriter(p.writer, 0, 0, 2, ' ', 0)
+	printTableRow(w, []string{"Metric", "Lower", "Upper", "Outliers"}, true)
+	for _, fence := range detection.Fences {
+		var prs []string
+		for _, m := range outlierMetrics(report, fence) {
+			prs = append(prs, fmt.Sprintf("#%d (%s)", m.PullRequest.Number, tableDuration(fence.Metric.Of(m))))
+		}
+		listed := strings.Join(prs, ", ")
+		if listed == "" {
+			listed = "-"
 		}
+		printTableRow(w, []string{
+			fence.Metric.Name,
+			tableDuration(&fence.Lower),
+			tableDuration(&fence.Upper),
+			listed,
+		}, false)
+	}
+	w.Flush()
+
+	fmt.Fprintln(p.writer)
+}
 
-		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\n",
-			pr.Number,
-			truncateString(pr.Author, 20),
-			pr.CreatedAt.Format("2006-01-02 15:04"),
-			timeToReview,
-			timeToApprove,
-			title,
-		)
+func (p *TablePrinter) printSurvival(survival []entity.MetricSurvival) {
+	if len(survival) == 0 {
+		return
 	}
 
+	fmt.Fprintln(p.writer, "=== Still Waiting (Kaplan-Meier) ===")
 	fmt.Fprintln(p.writer)
-	return nil
-}
\ No newline at end of file
+
+	w := tabwriter.NewWriter(p.writer, 0, 0, 2, ' ', 0)
+	header := []string{"Metric", "PRs", "Events", "Censored"}
+	for _, h := range entity.SurvivalHorizons {
+		header = append(header, h.Label)
+	}
+	printTableRow(w, append(header, "Median"), true)
+	for _, s := range survival {
+		c := s.Curve
+		row := []string{
+			s.Metric.Name,
+			fmt.Sprintf("%d", c.Observations),
+			fmt.Sprintf("%d", c.Events),
+			fmt.Sprintf("%d", c.Observations-c.Events),
+		}
+		for _, h := range entity.SurvivalHorizons {
+			row = append(row, tableProbability(c.StillWaiting(h.After)))
+		}
+		printTableRow(w, append(row, tableDuration(c.Median())), false)
+	}
+	w.Flush()
+
+	fmt.Fprintln(p.writer)
+}
+
+func (p *TablePrinter) printLatencyGroups(title, keyHeader string, groups []entity.LatencyGroup) {
+	if len(groups) == 0 {
+		return
+	}
+
+	fmt.Fprintf(p.writer, "=== %s ===\n\n", title)
+
+	w := tabwriter.NewWriter(p.writer, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "%s\t%s\n", keyHeader, latencyHeader)
+	fmt.Fprintf(w, "----\t%s\n", latencyRule)
+	for _, g := range groups {
+		fmt.Fprintf(w, "%s\t%s\n", g.Key, latencyCells(g))
+	}
+	w.Flush()
+
+	fmt.Fprintln(p.writer)
+}
+
+func (p *TablePrinter) printOutcomes(groups []entity.LatencyGroup) {
+	if len(groups) == 0 {
+		return
+	}
+
+	fmt.Fprintln(p.writer, "=== Latency by Outcome ===")
+	fmt.Fprintln(p.writer)
+
+	w := tabwriter.NewWriter(p.writer, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, latencyHeader)
+	fmt.Fprintln(w, latencyRule)
+	for _, g := range groups {
+		fmt.Fprintln(w, latencyCells(g))
+	}
+	w.Flush()
+
+	fmt.Fprintln(p.writer)
+}
+
+func (p *TablePrinter) printAreas(areas []entity.AreaLatency) {
+	if len(areas) == 0 {
+		return
+	}
+
+	fmt.Fprintln(p.writer, "=== Latency by CODEOWNERS Area ===")
+	fmt.Fprintln(p.writer)
+
+	w := tabwriter.NewWriter(p.writer, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "Area\t%s\tCode Owner Approved\n", latencyHeader)
+	fmt.Fprintf(w, "----\t%s\t-------------------\n", latencyRule)
+	for _, a := range areas {
+		fmt.Fprintf(w, "%s\t%s\t%d/%d\n", a.Key, latencyCells(a.LatencyGroup), a.CodeOwnerApproved, a.Approved)
+	}
+	w.Flush()
+
+	fmt.Fprintln(p.writer)
+}
+
+func (p *TablePrinter) printRework(groups []entity.ReworkGroup) {
+	if len(groups) == 0 {
+		return
+	}
+
+	fmt.Fprintln(p.writer, "=== Rework after First Review by Team ===")
+	fmt.Fprintln(p.writer)
+
+	w := tabwriter.NewWriter(p.writer, 0, 0, 2, ' ', 0)
+	printTableRow(w, []string{"Team", "Reviewed PRs", "Reworked", "Commits/PR", "Lines/PR", "Median Review to Last Push", "Mean Review to Last Push"}, true)
+	for _, g := range groups {
+		printTableRow(w, []string{
+			g.Team,
+			fmt.Sprintf("%d", g.PRs),
+			fmt.Sprintf("%d", g.Reworked),
+			fmt.Sprintf("%.1f", perPR(g.Commits, g.PRs)),
+			fmt.Sprintf("%.1f", perPR(g.Lines, g.PRs)),
+			tableSummary(g.ReviewToLastPush, g.ReviewToLastPush.Median),
+			tableSummary(g.ReviewToLastPush, g.ReviewToLastPush.Mean),
+		}, false)
+	}
+	w.Flush()
+
+	fmt.Fprintln(p.writer)
+}
+
+func (p *TablePrinter) printTeamWaits(groups []entity.TeamWait) {
+	if len(groups) == 0 {
+		return
+	}
+
+	fmt.Fprintln(p.writer, "=== Ball in Court by Team ===")
+	fmt.Fprintln(p.writer)
+
+	w := tabwriter.NewWriter(p.writer, 0, 0, 2, ' ', 0)
+	printTableRow(w, []string{"Team", "PRs", "Median Reviewer Wait", "Median Author Wait", "Median Idle After Approval", "Reviewer %", "Author %", "Idle %"}, true)
+	for _, g := range groups {
+		total := g.Total.Total()
+		printTableRow(w, []string{
+			g.Team,
+			fmt.Sprintf("%d", g.PRs),
+			tableSummary(g.ReviewerWait, g.ReviewerWait.Median),
+			tableSummary(g.AuthorWait, g.AuthorWait.Median),
+			tableSummary(g.IdleAfterApproval, g.IdleAfterApproval.Median),
+			fmt.Sprintf("%.0f%%", share(g.Total.ReviewerWait, total)),
+			fmt.Sprintf("%.0f%%", share(g.Total.AuthorWait, total)),
+			fmt.Sprintf("%.0f%%", share(g.Total.IdleAfterApproval, total)),
+		}, false)
+	}
+	w.Flush()
+
+	fmt.Fprintln(p.writer)
+}
+
+func (p *TablePrinter) printAuthorResponses(report *entity.Report) {
+	rows := authorResponses(report)
+	if len(rows) == 0 {
+		return
+	}
+
+	fmt.Fprintln(p.writer, "=== Author Response to Review Feedback ===")
+	fmt.Fprintln(p.writer)
+
+	w := tabwriter.NewWriter(p.writer, 0, 0, 2, ' ', 0)
+	printTableRow(w, []string{"Author", "PRs", "Feedback", "Unanswered", "Median Response", "Mean Response"}, true)
+	for _, a := range rows {
+		printTableRow(w, []string{
+			a.Author,
+			fmt.Sprintf("%d", a.PRs),
+			fmt.Sprintf("%d", a.Feedback),
+			fmt.Sprintf("%d", a.Unanswered),
+			tableSummary(a.ResponseTime, a.ResponseTime.Median),
+			tableSummary(a.ResponseTime, a.ResponseTime.Mean),
+		}, false)
+	}
+	w.Flush()
+
+	fmt.Fprintln(p.writer)
+}
+
+func (p *TablePrinter) printReviewerLoad(loads []entity.ReviewerLoad) {
+	if len(loads) == 0 {
+		return
+	}
+
+	fmt.Fprintln(p.writer, "=== Reviewer Load ===")
+	fmt.Fprintln(p.writer)
+
+	w := tabwriter.NewWriter(p.writer, 0, 0, 2, ' ', 0)
+	printTableRow(w, []string{"Reviewer", "Requests", "Max Queue", "Mean Daily Max", "Average Queue", "Median Response", "Mean Response", "Load/Response Correlation"}, true)
+	for _, l := range loads {
+		correlation := "N/A"
+		if l.LoadCorrelation != nil {
+			correlation = fmt.Sprintf("%+.2f", *l.LoadCorrelation)
+		}
+		printTableRow(w, []string{
+			truncateString(l.Reviewer, 30),
+			fmt.Sprintf("%d", l.Requests),
+			fmt.Sprintf("%d", l.MaxQueue),
+			fmt.Sprintf("%.1f", l.MeanDailyMax()),
+			fmt.Sprintf("%.2f", l.AverageQueue),
+			tableSummary(l.ResponseTime, l.ResponseTime.Median),
+			tableSummary(l.ResponseTime, l.ResponseTime.Mean),
+			correlation,
+		}, false)
+	}
+	w.Flush()
+
+	fmt.Fprintln(p.writer)
+}
+
+func (p *TablePrinter) printRubberStamps(report *entity.Report) {
+	if report.RubberStampRule == nil {
+		return
+	}
+
+	rule := report.RubberStampRule
+	fmt.Fprintln(p.writer, "=== Rubber-Stamp Approvals ===")
+	fmt.Fprintln(p.writer)
+	fmt.Fprintf(p.writer, "Flagged: approved within %d min on PRs with %d+ changed lines, or without comments on PRs with %d+ changed lines\n\n",
+		formatDuration(rule.FastApproval), rule.FastApprovalMinLines, rule.SilentApprovalMinLines)
+
+	if len(report.RubberStamps) == 0 {
+		fmt.Fprintln(p.writer, "No approvals flagged")
+		fmt.Fprintln(p.writer)
+		return
+	}
+
+	w := tabwriter.NewWriter(p.writer, 0, 0, 2, ' ', 0)
+	printTableRow(w, []string{"PR #", "Author", "Size", "Reviewer", "Approved After", "Reasons", "Title"}, true)
+	for _, m := range report.Metrics {
+		pr := m.PullRequest
+		for _, s := range m.RubberStamps {
+			printTableRow(w, []string{
+				fmt.Sprintf("%d", pr.Number),
+				truncateString(pr.Author, 20),
+				fmt.Sprintf("%s (+%d/-%d)", m.Size, pr.Additions, pr.Deletions),
+				truncateString(s.Reviewer, 20),
+				tableDuration(&s.ApprovedAfter),
+				rubberStampReasons(s.Reasons),
+				truncateString(pr.Title, 60),
+			}, false)
+		}
+	}
+	w.Flush()
+	fmt.Fprintln(p.writer)
+
+	w = tabwriter.NewWriter(p.writer, 0, 0, 2, ' ', 0)
+	printTableRow(w, []string{"Reviewer", "Approvals", "Flagged", "Fast", "No Comments"}, true)
+	for _, c := range report.RubberStamps {
+		printTableRow(w, []string{
+			c.Reviewer,
+			fmt.Sprintf("%d", c.Approvals),
+			fmt.Sprintf("%d", c.Flagged),
+			fmt.Sprintf("%d", c.Fast),
+			fmt.Sprintf("%d", c.Silent),
+		}, false)
+	}
+	w.Flush()
+
+	fmt.Fprintln(p.writer)
+}
+
+// tableOutliers lists the metrics detected as outliers for the PR, or N/A without detection
+func tableOutliers(detection *entity.OutlierDetection, m *entity.ReviewMetrics) string {
+	if detection == nil {
+		return "N/A"
+	}
+	if len(m.Outliers) == 0 {
+		return "-"
+	}
+	return strings.Join(outlierNames(m), ", ")
+}
+
+// tableFeedback formats the median time the author took to respond to feedback on the PR,
+// with how many of the feedback reviews were answered
+func tableFeedback(responses []entity.FeedbackResponse) string {
+	if len(responses) == 0 {
+		return "N/A"
+	}
+	s := feedbackSummary(responses)
+	return fmt.Sprintf("%s (%d/%d)", tableSummary(s, s.Median), s.Count, len(responses))
+}
+
+// tableReviewDepth formats the review comments, the number of commenters and comments per 100 changed lines
+func tableReviewDepth(d entity.ReviewDepth) string {
+	return fmt.Sprintf("%d by %d (%.1f/100L)", d.Comments, d.Commenters, d.CommentsPer100Lines)
+}
+
+// tableRework formats the commits and changed lines after the first review
+func tableRework(r *entity.Rework) string {
+	if r == nil {
+		return "N/A"
+	}
+	return fmt.Sprintf("%d (+%d/-%d)", r.Commits, r.Additions, r.Deletions)
+}
+
+const (
+	latencyHeader = "Outcome\tPRs\tUnreviewed\tMedian Review\tMean Review\tMedian Approve\tMean Approve"
+	latencyRule   = "-------\t---\t----------\t-------------\t-----------\t--------------\t------------"
+)
+
+func latencyCells(g entity.LatencyGroup) string {
+	return fmt.Sprintf("%s\t%d\t%d\t%s\t%s\t%s\t%s",
+		outcomeLabel(g.Outcome),
+		g.Count,
+		g.Unreviewed,
+		tableSummary(g.TimeToReview, g.TimeToReview.Median),
+		tableSummary(g.TimeToReview, g.TimeToReview.Mean),
+		tableSummary(g.TimeToApprove, g.TimeToApprove.Median),
+		tableSummary(g.TimeToApprove, g.TimeToApprove.Mean),
+	)
+}
+
+func (p *TablePrinter) printExclusions(exclusions []entity.Exclusion) {
+	if len(exclusions) == 0 {
+		return
+	}
+
+	fmt.Fprintln(p.writer, "=== Excluded by Filters ===")
+	fmt.Fprintln(p.writer)
+
+	w := tabwriter.NewWriter(p.writer, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "Rule\tExcluded")
+	fmt.Fprintln(w, "----\t--------")
+	for _, e := range exclusions {
+		fmt.Fprintf(w, "%s\t%d\n", e.Rule, e.Count)
+	}
+	w.Flush()
+
+	fmt.Fprintln(p.writer)
+}
+
+// outcomeLabel names the outcome of a group, which is empty when outcomes are combined
+func outcomeLabel(o entity.Outcome) string {
+	if o == "" {
+		return "all"
+	}
+	return string(o)
+}
+
+func tableDuration(d *time.Duration) string {
+	if d == nil {
+		return "N/A"
+	}
+	return fmt.Sprintf("%d min", formatDuration(*d))
+}
+
+// tableProbability formats a probability in percent, or N/A when it is unknown
+func tableProbability(v *float64) string {
+	if v == nil {
+		return "N/A"
+	}
+	return fmt.Sprintf("%.1f%%", *v*100)
+}
+
+// tableSummary formats a statistic of the summary, or N/A when the summary is empty
+func tableSummary(s entity.DurationSummary, d time.Duration) string {
+	if s.Count == 0 {
+		return "N/A"
+	}
+	return tableDuration(&d)
+}
+
+// printTableRow writes tab-separated cells, followed by a dashed rule when it is a header
+func printTableRow(w io.Writer, cells []string, header bool) {
+	fmt.Fprintln(w, strings.Join(cells, "\t"))
+	if !header {
+		return
+	}
+	rule := make([]string, len(cells))
+	for i, c := range cells {
+		rule[i] = strings.Repeat("-", len(c))
+	}
+	fmt.Fprintln(w, strings.Join(rule, "\t"))
+}
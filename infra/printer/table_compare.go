@@ -0,0 +1,157 @@
+package printer
+
+import (
+	"fmt"
+	"text/tabwriter"
+	"time"
+
+	"github.com/dragoneena12/measure-review-time/domain/entity"
+)
+
+func (p *TablePrinter) PrintComparison(report *entity.ComparisonReport) error {
+	fmt.Fprintf(p.writer, "\n=== PR Review Time Comparison for %s/%s ===\n\n", report.Owner, report.Repo)
+
+	w := tabwriter.NewWriter(p.writer, 0, 0, 2, ' ', 0)
+	printTableRow(w, []string{"Group", "PRs of", "Outcome", "PRs", "No Review", "No Approval"}, true)
+	for _, g := range []struct {
+		name  string
+		group entity.ComparisonGroup
+	}{{"Baseline", report.Baseline}, {"Current", report.Current}} {
+		s := g.group.Summary
+		printTableRow(w, []string{
+			g.name,
+			g.group.Label,
+			outcomeLabel(s.Outcome),
+			fmt.Sprintf("%d", s.PRs),
+			fmt.Sprintf("%d (%.1f%%)", s.Unreviewed, percent(s.Unreviewed, s.PRs)),
+			fmt.Sprintf("%d (%.1f%%)", s.Unapproved, percent(s.Unapproved, s.PRs)),
+		}, false)
+	}
+	w.Flush()
+	fmt.Fprintln(p.writer)
+	if report.Overlapping > 0 {
+		fmt.Fprintf(p.writer, "Left out %d PRs matching both groups\n\n", report.Overlapping)
+	}
+
+	if len(report.Metrics) == 0 {
+		p.printExclusions(report.Exclusions)
+		p.printGroupExclusions(report)
+		return nil
+	}
+
+	fmt.Fprintln(p.writer, "=== Metrics ===")
+	fmt.Fprintln(p.writer)
+
+	w = tabwriter.NewWriter(p.writer, 0, 0, 2, ' ', 0)
+	printTableRow(w, []string{"Metric", "Statistic", "Baseline", "Current", "Delta", "Change"}, true)
+	for _, m := range report.Metrics {
+		for _, stat := range entity.ComparedStatistics {
+			delta, relative := m.Delta(stat)
+			deltaCell := "N/A"
+			if m.Baseline.Count > 0 && m.Current.Count > 0 {
+				deltaCell = tableDelta(delta)
+			}
+			printTableRow(w, []string{
+				m.Metric.Name,
+				stat.Name,
+				tableSummary(m.Baseline, stat.Of(m.Baseline)),
+				tableSummary(m.Current, stat.Of(m.Current)),
+				deltaCell,
+				tableChange(relative),
+			}, false)
+		}
+	}
+	w.Flush()
+	fmt.Fprintln(p.writer)
+
+	fmt.Fprintln(p.writer, "=== Median Difference (bootstrap confidence interval) ===")
+	fmt.Fprintln(p.writer)
+
+	w = tabwriter.NewWriter(p.writer, 0, 0, 2, ' ', 0)
+	printTableRow(w, []string{"Metric", "Baseline PRs", "Current PRs", "Difference", "Interval", "Beyond Noise"}, true)
+	for _, m := range report.Metrics {
+		difference, interval, beyondNoise := "N/A", "N/A", "N/A"
+		if ci := m.MedianDifference; ci != nil {
+			difference = tableDelta(m.Current.Median - m.Baseline.Median)
+			interval = fmt.Sprintf("%.0f%%: %s .. %s", ci.Level*100, tableDelta(ci.Lower), tableDelta(ci.Upper))
+			beyondNoise = "no"
+			if ci.ExcludesZero() {
+				beyondNoise = "yes"
+			}
+		}
+		printTableRow(w, []string{
+			m.Metric.Name,
+			fmt.Sprintf("%d", m.Baseline.Count),
+			fmt.Sprintf("%d", m.Current.Count),
+			difference,
+			interval,
+			beyondNoise,
+		}, false)
+	}
+	w.Flush()
+	fmt.Fprintln(p.writer)
+
+	fmt.Fprintln(p.writer, "=== Mann-Whitney U Test ===")
+	fmt.Fprintln(p.writer)
+
+	w = tabwriter.NewWriter(p.writer, 0, 0, 2, ' ', 0)
+	printTableRow(w, []string{"Metric", "U", "Z", "p-value", "Holm p-value", "Effect Size", "Significant"}, true)
+	for _, m := range report.Metrics {
+		if m.Test == nil {
+			printTableRow(w, []string{m.Metric.Name, "N/A", "N/A", "N/A", "N/A", "N/A", "N/A"}, false)
+			continue
+		}
+		significant := "no"
+		if m.Significant(significanceLevel) {
+			significant = "yes"
+		}
+		printTableRow(w, []string{
+			m.Metric.Name,
+			fmt.Sprintf("%.1f", m.Test.U),
+			fmt.Sprintf("%+.2f", m.Test.Z),
+			fmt.Sprintf("%.4f", m.Test.PValue),
+			fmt.Sprintf("%.4f", m.Test.AdjustedPValue),
+			fmt.Sprintf("%+.2f (%s)", m.Test.EffectSize, effectMagnitude(m.Test.EffectSize)),
+			significant,
+		}, false)
+	}
+	w.Flush()
+	fmt.Fprintln(p.writer)
+
+	p.printExclusions(report.Exclusions)
+	p.printGroupExclusions(report)
+	return nil
+}
+
+// printGroupExclusions writes the filter exclusions of groups measured separately side by side
+func (p *TablePrinter) printGroupExclusions(report *entity.ComparisonReport) {
+	exclusions := groupExclusions(report)
+	if len(exclusions) == 0 {
+		return
+	}
+
+	fmt.Fprintln(p.writer, "=== Excluded by Filters ===")
+	fmt.Fprintln(p.writer)
+
+	w := tabwriter.NewWriter(p.writer, 0, 0, 2, ' ', 0)
+	printTableRow(w, []string{"Rule", "Baseline", "Current"}, true)
+	for _, e := range exclusions {
+		printTableRow(w, []string{e.Rule, fmt.Sprintf("%d", e.Baseline), fmt.Sprintf("%d", e.Current)}, false)
+	}
+	w.Flush()
+
+	fmt.Fprintln(p.writer)
+}
+
+// tableDelta formats a signed duration difference
+func tableDelta(d time.Duration) string {
+	return fmt.Sprintf("%+d min", formatDuration(d))
+}
+
+// tableChange formats a relative change, or N/A when there is none
+func tableChange(relative *float64) string {
+	if relative == nil {
+		return "N/A"
+	}
+	return fmt.Sprintf("%+.1f%%", *relative)
+}
@@ -0,0 +1,81 @@
+package printer
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/dragoneena12/measure-review-time/domain/entity"
+)
+
+func (p *TablePrinter) PrintOpenPRs(report *entity.AgingReport) error {
+	if len(report.PullRequests) == 0 {
+		fmt.Fprintln(p.writer, "No open pull requests found")
+		p.printExclusions(report.Exclusions)
+		return nil
+	}
+
+	fmt.Fprintf(p.writer, "\n=== Open PR Review Debt for %s/%s (as of %s) ===\n\n", report.Owner, report.Repo, report.GeneratedAt.Format("2006-01-02 15:04"))
+
+	w := tabwriter.NewWriter(p.writer, 0, 0, 2, ' ', 0)
+	printTableRow(w, []string{"PR #", "Author", "Created", "Size", "Stage", "Waiting", "Approvals", "Requested Reviewers", "Title"}, true)
+
+	for _, open := range report.PullRequests {
+		pr := open.Metrics.PullRequest
+
+		waiting := "-"
+		if open.Stage != entity.StageApproved {
+			waiting = tableDuration(&open.Waiting)
+		}
+
+		requested := strings.Join(pr.RequestedReviewers, ", ")
+		if requested == "" {
+			requested = "-"
+		}
+
+		printTableRow(w, []string{
+			fmt.Sprintf("%d", pr.Number),
+			truncateString(pr.Author, 20),
+			pr.CreatedAt.Format("2006-01-02 15:04"),
+			string(open.Metrics.Size),
+			string(open.Stage),
+			waiting,
+			fmt.Sprintf("%d/%d", len(pr.Approvals), max(pr.RequiredApprovals, 1)),
+			truncateString(requested, 40),
+			truncateString(pr.Title, 60),
+		}, false)
+	}
+	w.Flush()
+	fmt.Fprintln(p.writer)
+
+	debt := report.Debt
+	fmt.Fprintln(p.writer, "=== Review Debt ===")
+	fmt.Fprintln(p.writer)
+
+	w = tabwriter.NewWriter(p.writer, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "Open PRs\t%d\n", debt.OpenPRs)
+	fmt.Fprintf(w, "Awaiting review\t%d\n", debt.AwaitingReview)
+	fmt.Fprintf(w, "Awaiting approval\t%d\n", debt.AwaitingApproval)
+	fmt.Fprintf(w, "Approved, not merged\t%d\n", debt.Approved)
+	fmt.Fprintf(w, "Total wait\t%s\n", tableSummary(debt.Wait, debt.TotalWait))
+	fmt.Fprintf(w, "Median wait\t%s\n", tableSummary(debt.Wait, debt.Wait.Median))
+	fmt.Fprintf(w, "Longest wait\t%s\n", tableSummary(debt.Wait, debt.LongestWait))
+	w.Flush()
+	fmt.Fprintln(p.writer)
+
+	if len(debt.ByReviewer) > 0 {
+		fmt.Fprintln(p.writer, "=== Pending Review Requests by Reviewer ===")
+		fmt.Fprintln(p.writer)
+
+		w = tabwriter.NewWriter(p.writer, 0, 0, 2, ' ', 0)
+		printTableRow(w, []string{"Reviewer", "Requested", "Longest Wait"}, true)
+		for _, r := range debt.ByReviewer {
+			printTableRow(w, []string{r.Reviewer, fmt.Sprintf("%d", r.Requested), tableDuration(&r.LongestWait)}, false)
+		}
+		w.Flush()
+		fmt.Fprintln(p.writer)
+	}
+
+	p.printExclusions(report.Exclusions)
+	return nil
+}
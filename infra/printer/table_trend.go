@@ -0,0 +1,65 @@
+package printer
+
+import (
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/dragoneena12/measure-review-time/domain/entity"
+)
+
+func (p *TablePrinter) PrintTrend(report *entity.TrendReport) error {
+	if len(report.Buckets) == 0 {
+		fmt.Fprintln(p.writer, "No pull requests found")
+		p.printExclusions(report.Exclusions)
+		return nil
+	}
+
+	fmt.Fprintf(p.writer, "\n=== PR Review Time Trend for %s/%s (per %s, by %s date) ===\n\n", report.Owner, report.Repo, report.Interval, report.By)
+
+	fmt.Fprintf(p.writer, "Summarizing %s PRs\n\n", outcomeLabel(report.Buckets[0].Summary.Outcome))
+
+	w := tabwriter.NewWriter(p.writer, 0, 0, 2, ' ', 0)
+	printTableRow(w, []string{"Bucket", "PRs", "No Review", "No Approval"}, true)
+	for _, b := range report.Buckets {
+		s := b.Summary
+		printTableRow(w, []string{
+			report.Interval.Label(b.Start),
+			fmt.Sprintf("%d", s.PRs),
+			fmt.Sprintf("%d (%.1f%%)", s.Unreviewed, percent(s.Unreviewed, s.PRs)),
+			fmt.Sprintf("%d (%.1f%%)", s.Unapproved, percent(s.Unapproved, s.PRs)),
+		}, false)
+	}
+	w.Flush()
+
+	fmt.Fprintln(p.writer)
+	if report.Undated > 0 {
+		fmt.Fprintf(p.writer, "%d PRs without a %s date are left out\n\n", report.Undated, report.By)
+	}
+
+	for _, metric := range report.Metrics() {
+		fmt.Fprintf(p.writer, "=== %s ===\n\n", metric.Name)
+
+		w := tabwriter.NewWriter(p.writer, 0, 0, 2, ' ', 0)
+		printTableRow(w, []string{"Bucket", "PRs", "Mean", "Median", "P75", "P90", "P95", "Min", "Max"}, true)
+		for _, b := range report.Buckets {
+			s := b.Summary.Metric(metric.Key)
+			printTableRow(w, []string{
+				report.Interval.Label(b.Start),
+				fmt.Sprintf("%d", s.Count),
+				tableSummary(s, s.Mean),
+				tableSummary(s, s.Median),
+				tableSummary(s, s.P75),
+				tableSummary(s, s.P90),
+				tableSummary(s, s.P95),
+				tableSummary(s, s.Min),
+				tableSummary(s, s.Max),
+			}, false)
+		}
+		w.Flush()
+
+		fmt.Fprintln(p.writer)
+	}
+
+	p.printExclusions(report.Exclusions)
+	return nil
+}